@@ -4,51 +4,226 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"strings"
 	"time"
 
+	"github.com/caiolandgraf/grove/internal/watcher"
 	"github.com/spf13/cobra"
 )
 
-var buildOutput string
+var (
+	buildOutput  string
+	buildApp     string
+	buildAll     bool
+	buildRelease bool
+)
 
 var buildCmd = &cobra.Command{
 	Use:   "build",
 	Short: "Compile the application to a binary",
 	Long: bold("build") + ` compiles the application and outputs the binary.
 
+Projects with several binaries declare them as ` + colorCyan + `[[app]]` + colorReset + ` tables in ` + colorCyan + `grove.toml` + colorReset + `:
+
+  ` + colorGray + `[[app]]` + colorReset + `
+  ` + colorGray + `name = "worker"` + colorReset + `
+  ` + colorGray + `main = "./cmd/worker"` + colorReset + `
+
+Without ` + colorGreen + `--app` + colorReset + ` or ` + colorGreen + `--all` + colorReset + ` the first app is built. Each app is compiled to
+` + colorCyan + `./bin/<name>` + colorReset + `; ` + colorGreen + `-o` + colorReset + ` overrides the path when a single app is built.
+
+` + colorBold + `Release builds` + colorReset + `
+  The ` + colorCyan + `[build]` + colorReset + ` section applies to every build:
+
+  ` + colorGray + `[build]` + colorReset + `
+  ` + colorGray + `targets  = ["linux/amd64", "linux/arm64", "darwin/arm64"]` + colorReset + `
+  ` + colorGray + `ldflags  = "-s -w"` + colorReset + `
+  ` + colorGray + `trimpath = true` + colorReset + `
+  ` + colorGray + `cgo      = false` + colorReset + `
+  ` + colorGray + `tags     = ["netgo"]` + colorReset + `
+
+  When ` + colorCyan + `internal/version` + colorReset + ` exists, its Version, Commit and Date are set
+  from git at link time. ` + colorGreen + `--release` + colorReset + ` creates that package if needed, compiles
+  every app (or ` + colorGreen + `--app` + colorReset + `) for each target into ` + colorCyan + `dist/<app>_<os>_<arch>` + colorReset + ` and
+  writes their SHA256 to ` + colorCyan + `dist/checksums.txt` + colorReset + `. The artifacts of the previous release are
+  removed first; other files in ` + colorCyan + `dist/` + colorReset + ` are kept.
+
 ` + colorGray + `Examples:` + colorReset + `
   grove build
-  grove build -o ./bin/my-api`,
+  grove build -o ./bin/my-api
+  grove build --app worker
+  grove build --all
+  grove build --release`,
+	Args: cobra.NoArgs,
 	RunE: runBuild,
 }
 
 func init() {
 	buildCmd.Flags().StringVarP(
 		&buildOutput,
-		"output", "o", "./bin/app",
-		"Output path for the compiled binary",
+		"output", "o", "",
+		"Output path for the compiled binary (default ./bin/<app>)",
+	)
+	buildCmd.Flags().StringVar(
+		&buildApp,
+		"app", "",
+		"Name of the [[app]] to build",
 	)
+	buildCmd.Flags().BoolVar(
+		&buildAll,
+		"all", false,
+		"Build every [[app]] declared in grove.toml",
+	)
+	buildCmd.Flags().BoolVar(
+		&buildRelease,
+		"release", false,
+		"Cross-compile the [build] targets into dist/ with SHA256 checksums",
+	)
+	buildCmd.MarkFlagsMutuallyExclusive("app", "all")
+	buildCmd.MarkFlagsMutuallyExclusive("output", "release")
 }
 
 func runBuild(_ *cobra.Command, _ []string) error {
+	cfg, err := watcher.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load grove.toml: %w", err)
+	}
+	project, err := loadProjectConfig()
+	if err != nil {
+		return err
+	}
+
+	apps, err := selectApps(
+		cfg.Apps, buildApp,
+		// A release covers every app unless one is named.
+		buildAll || (buildRelease && buildApp == ""),
+	)
+	if err != nil {
+		return err
+	}
+	if buildRelease {
+		return runRelease(project.Build, apps)
+	}
+	if buildOutput != "" && len(apps) > 1 {
+		return fmt.Errorf("-o cannot be used when building %d apps", len(apps))
+	}
+
+	if err := ensureDir("bin"); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	var version *versionInfo
+	if fileExists(versionPkgPath) {
+		v := gitVersion()
+		version = &v
+	}
+
+	for _, app := range apps {
+		output := buildOutput
+		if output == "" {
+			output = "./" + filepath.ToSlash(filepath.Join("bin", app.Name))
+		}
+		args := goBuildArgs(project.Build, output, app.Main, version)
+		if err := buildBinary(output, args, buildEnv(project.Build, nil)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// runRelease cross-compiles apps for every [build] target into dist/ and
+// writes dist/checksums.txt.
+func runRelease(cfg buildConfig, apps []watcher.App) error {
 	fmt.Println()
+	if err := scaffoldVersionPackage(); err != nil {
+		return err
+	}
+
+	v := gitVersion()
 	fmt.Printf(
-		"  %s  %s\n",
-		badge(colorBgBlue, "BUILDING"),
-		gray("go build -o "+buildOutput+" ./cmd/api/"),
+		"  %sReleasing%s %s %s\n",
+		colorGray, colorReset,
+		bold(v.Version),
+		gray("("+v.Commit+", "+v.Date+")"),
 	)
-	fmt.Println()
 
-	if err := ensureDir("bin"); err != nil {
+	if err := cleanRelease(distDir); err != nil {
+		return fmt.Errorf("failed to clean %s: %w", distDir, err)
+	}
+	if err := ensureDir(distDir); err != nil {
 		return fmt.Errorf("failed to create output directory: %w", err)
 	}
 
+	var artifacts []string
+	for _, app := range apps {
+		for _, target := range releaseTargets(cfg) {
+			name := artifactName(app.Name, target)
+			output := "./" + filepath.ToSlash(filepath.Join(distDir, name))
+			args := goBuildArgs(cfg, output, app.Main, &v)
+			if err := buildBinary(output, args, buildEnv(cfg, &target)); err != nil {
+				return err
+			}
+			artifacts = append(artifacts, name)
+		}
+	}
+
+	if err := writeChecksums(distDir, artifacts); err != nil {
+		return err
+	}
+
+	fmt.Println(done(fmt.Sprintf(
+		"%d artifact(s) and %s written to %s",
+		len(artifacts),
+		colorCyan+checksumsFile+colorReset,
+		colorCyan+distDir+"/"+colorReset,
+	)))
+	fmt.Println()
+
+	return nil
+}
+
+// selectApps returns the apps named by --app / --all, or the first declared
+// app when neither is given.
+func selectApps(apps []watcher.App, name string, all bool) ([]watcher.App, error) {
+	switch {
+	case all:
+		return apps, nil
+	case name == "":
+		return apps[:1], nil
+	}
+
+	names := make([]string, 0, len(apps))
+	for _, app := range apps {
+		if app.Name == name {
+			return []watcher.App{app}, nil
+		}
+		names = append(names, app.Name)
+	}
+	return nil, fmt.Errorf(
+		"unknown app %q (declared in grove.toml: %s)",
+		name, strings.Join(names, ", "),
+	)
+}
+
+// buildBinary runs "go <args>" with env, compiling the binary at output.
+func buildBinary(output string, args, env []string) error {
+	fmt.Println()
+	fmt.Printf(
+		"  %s  %s\n",
+		badge(colorBgBlue, "BUILDING"),
+		gray("go "+shellArgs(args)),
+	)
+	fmt.Println()
+
 	start := time.Now()
 
 	bw := newBuildOutputWriter(os.Stderr)
-	c := exec.Command("go", "build", "-o", buildOutput, "./cmd/api/")
+	c := exec.Command("go", args...)
 	c.Stdout = bw
 	c.Stderr = bw
+	c.Env = env
 
 	if err := c.Run(); err != nil {
 		fmt.Println()
@@ -61,10 +236,23 @@ func runBuild(_ *cobra.Command, _ []string) error {
 
 	fmt.Println()
 	fmt.Println(done(
-		"Binary compiled to " + colorCyan + buildOutput + colorReset +
+		"Binary compiled to " + colorCyan + output + colorReset +
 			"  " + gray("("+fmtDuration(elapsed)+")"),
 	))
 	fmt.Println()
 
 	return nil
 }
+
+// shellArgs joins args for display, quoting the ones containing spaces so
+// the printed command can be pasted into a shell.
+func shellArgs(args []string) string {
+	quoted := make([]string, len(args))
+	for i, a := range args {
+		if strings.ContainsAny(a, " \t") {
+			a = "'" + a + "'"
+		}
+		quoted[i] = a
+	}
+	return joinArgs(quoted)
+}
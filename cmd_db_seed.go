@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	dbSeedEnv     string
+	dbSeedClasses []string
+)
+
+var dbSeedCmd = &cobra.Command{
+	Use:   "db:seed",
+	Short: "Run the database seeders",
+	Long: bold("db:seed") + ` compiles and runs the seeders registered in
+` + colorCyan + `internal/database/seeders/seeders.go` + colorReset + `, in the order they are listed.
+
+Use ` + colorGreen + `--class` + colorReset + ` to run specific seeders only. The database of ` + colorGreen + `--env` + colorReset + ` is
+passed to the app as DATABASE_URL.
+
+` + colorGray + `Examples:` + colorReset + `
+  grove db:seed
+  grove db:seed --class UserSeeder
+  grove db:seed --class User --class Post --env dev`,
+	Args: cobra.NoArgs,
+	RunE: runDBSeed,
+}
+
+func init() {
+	dbSeedCmd.Flags().StringVar(
+		&dbSeedEnv,
+		"env", "local",
+		"Environment whose database is seeded (local, dev, production)",
+	)
+	dbSeedCmd.Flags().StringSliceVar(
+		&dbSeedClasses,
+		"class", nil,
+		"Seeder to run (repeatable); runs all seeders when omitted",
+	)
+}
+
+func runDBSeed(cmd *cobra.Command, args []string) error {
+	db, err := loadDatabaseConfig()
+	if err != nil {
+		return err
+	}
+	return runSeeders(db, dbSeedEnv, dbSeedClasses)
+}
+
+// runSeeders runs "go run ./cmd/seed" with the selected seeder names against
+// the database of env.
+func runSeeders(db databaseConfig, env string, classes []string) error {
+	if !fileExists(filepath.Join(seedMainDir, "main.go")) {
+		return fmt.Errorf(
+			"no seeders found in %s\n\n  Create one with: %s",
+			seedersDir,
+			colorGreen+"grove make:seeder <Name>"+colorReset,
+		)
+	}
+
+	goArgs := append([]string{"run", "./" + filepath.ToSlash(seedMainDir)}, classes...)
+
+	fmt.Println()
+	fmt.Printf(
+		"  %sSeeding database%s %s\n",
+		colorGray, colorReset,
+		gray("(go "+joinArgs(goArgs)+" --env "+env+")"),
+	)
+	fmt.Println()
+
+	c := exec.Command("go", goArgs...)
+	c.Stdout = newIndentWriter(os.Stdout, "    ")
+	c.Stderr = newIndentWriter(os.Stderr, "    ")
+	c.Env = os.Environ()
+	if url := rawDatabaseURL(db, env); url != "" {
+		c.Env = append(c.Env, "DATABASE_URL="+url)
+	}
+
+	if err := c.Run(); err != nil {
+		return fmt.Errorf("failed to seed the database: %w", err)
+	}
+
+	fmt.Println()
+	fmt.Printf(
+		"  %s DONE %s  Database seeded.\n",
+		colorBgGreen, colorReset,
+	)
+	fmt.Println()
+
+	return nil
+}
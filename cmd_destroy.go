@@ -0,0 +1,394 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// ──────────────────────────────────────────────
+// Shared flags
+// ──────────────────────────────────────────────
+
+var destroyForce bool
+
+// addDestroyFlags registers the flags shared by every destroy:* command.
+func addDestroyFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVarP(
+		&destroyForce,
+		"force", "f", false,
+		"Also delete files that were modified after generation",
+	)
+}
+
+// destroyLong builds the help text shared by the destroy:* commands.
+func destroyLong(use, what string, examples ...string) string {
+	ex := ""
+	for _, e := range examples {
+		ex += "\n  " + e
+	}
+	return bold(use) + ` removes ` + what + `.
+
+Only files grove would have generated for the name are touched. A file that
+was edited after generation is kept unless ` + colorGreen + `--force` + colorReset + ` is given; grove compares
+it with the copy recorded in ` + colorCyan + `.grove/generated/` + colorReset + ` (or with a fresh render when
+no record exists).
+
+` + colorGray + `Examples:` + colorReset + ex
+}
+
+// ──────────────────────────────────────────────
+// destroy:model
+// ──────────────────────────────────────────────
+
+var (
+	destroyModelWithController bool
+	destroyModelWithDTO        bool
+	destroyModelResource       bool
+)
+
+var destroyModelCmd = &cobra.Command{
+	Use:   "destroy:model <Name>",
+	Short: "Remove a model generated by make:model",
+	Long: destroyLong(
+		"destroy:model",
+		"the model in "+colorCyan+"internal/models/"+colorReset+
+			" — and with "+colorGreen+"-c"+colorReset+" / "+colorGreen+"-d"+colorReset+" / "+colorGreen+"-r"+colorReset+
+			" the controller, routes and DTO too",
+		"grove destroy:model Post",
+		"grove destroy:model Post -r",
+		"grove destroy:model Post --force",
+	),
+	Args: cobra.ExactArgs(1),
+	RunE: runDestroyModel,
+}
+
+func init() {
+	destroyModelCmd.Flags().BoolVarP(
+		&destroyModelWithController,
+		"controller", "c", false,
+		"Also remove the controller and its routes",
+	)
+	destroyModelCmd.Flags().BoolVarP(
+		&destroyModelWithDTO,
+		"dto", "d", false,
+		"Also remove the DTO file",
+	)
+	destroyModelCmd.Flags().BoolVarP(
+		&destroyModelResource,
+		"resource", "r", false,
+		"Full resource — shorthand for -c -d",
+	)
+	addDestroyFlags(destroyModelCmd)
+}
+
+func runDestroyModel(_ *cobra.Command, args []string) error {
+	name := toPascalCase(toSingular(args[0]))
+
+	if destroyModelResource {
+		destroyModelWithController = true
+		destroyModelWithDTO = true
+	}
+
+	printDestroyHeader("model", name)
+
+	if err := destroyModel(name); err != nil {
+		return err
+	}
+	if destroyModelWithController {
+		if err := destroyController(name); err != nil {
+			return err
+		}
+	}
+	if destroyModelWithDTO {
+		if err := destroyRequest(name); err != nil {
+			return err
+		}
+	}
+
+	fmt.Println()
+	return nil
+}
+
+// ──────────────────────────────────────────────
+// destroy:controller
+// ──────────────────────────────────────────────
+
+var destroyControllerCmd = &cobra.Command{
+	Use:   "destroy:controller <Name>",
+	Short: "Remove a controller and its routes",
+	Long: destroyLong(
+		"destroy:controller",
+		"the controller in "+colorCyan+"internal/controllers/"+colorReset+
+			" and unregisters its CRUD routes from "+colorCyan+"internal/routes/"+colorReset,
+		"grove destroy:controller Post",
+	),
+	Args: cobra.ExactArgs(1),
+	RunE: runDestroyController,
+}
+
+func init() {
+	addDestroyFlags(destroyControllerCmd)
+}
+
+func runDestroyController(_ *cobra.Command, args []string) error {
+	name := toPascalCase(toSingular(args[0]))
+
+	printDestroyHeader("controller", name)
+
+	if err := destroyController(name); err != nil {
+		return err
+	}
+
+	fmt.Println()
+	return nil
+}
+
+// ──────────────────────────────────────────────
+// destroy:dto
+// ──────────────────────────────────────────────
+
+var destroyDtoCmd = &cobra.Command{
+	Use:   "destroy:dto <Name>",
+	Short: "Remove a DTO file generated by make:dto",
+	Long: destroyLong(
+		"destroy:dto",
+		"the DTO file in "+colorCyan+"internal/dto/"+colorReset,
+		"grove destroy:dto Post",
+	),
+	Args: cobra.ExactArgs(1),
+	RunE: runDestroyDto,
+}
+
+func init() {
+	addDestroyFlags(destroyDtoCmd)
+}
+
+func runDestroyDto(_ *cobra.Command, args []string) error {
+	name := toPascalCase(toSingular(args[0]))
+
+	printDestroyHeader("DTO", name)
+
+	if err := destroyRequest(name); err != nil {
+		return err
+	}
+
+	fmt.Println()
+	return nil
+}
+
+// ──────────────────────────────────────────────
+// destroy:middleware
+// ──────────────────────────────────────────────
+
+var destroyMiddlewareCmd = &cobra.Command{
+	Use:   "destroy:middleware <Name>",
+	Short: "Remove a middleware generated by make:middleware",
+	Long: destroyLong(
+		"destroy:middleware",
+		"the middleware in "+colorCyan+"internal/middleware/"+colorReset,
+		"grove destroy:middleware Auth",
+	),
+	Args: cobra.ExactArgs(1),
+	RunE: runDestroyMiddleware,
+}
+
+func init() {
+	addDestroyFlags(destroyMiddlewareCmd)
+}
+
+func runDestroyMiddleware(_ *cobra.Command, args []string) error {
+	name := toPascalCase(args[0])
+
+	printDestroyHeader("middleware", name)
+
+	if _, err := destroyFile("Middleware", name, middlewarePath(name), func() ([]byte, error) {
+		return renderMiddleware(name)
+	}); err != nil {
+		return err
+	}
+
+	fmt.Println()
+	return nil
+}
+
+// ──────────────────────────────────────────────
+// destroy:resource
+// ──────────────────────────────────────────────
+
+var destroyResourceCmd = &cobra.Command{
+	Use:   "destroy:resource <Name>",
+	Short: "Remove model + controller + DTO and the routes at once",
+	Long: destroyLong(
+		"destroy:resource",
+		"everything "+colorGreen+"make:resource"+colorReset+" created: model, controller, DTO and routes",
+		"grove destroy:resource Post",
+		"grove destroy:resource Posts --force",
+	),
+	Args: cobra.ExactArgs(1),
+	RunE: runDestroyResource,
+}
+
+func init() {
+	addDestroyFlags(destroyResourceCmd)
+}
+
+func runDestroyResource(_ *cobra.Command, args []string) error {
+	name := toPascalCase(toSingular(args[0]))
+
+	printDestroyHeader("resource", name)
+
+	if err := destroyModel(name); err != nil {
+		return err
+	}
+	if err := destroyController(name); err != nil {
+		return err
+	}
+	if err := destroyRequest(name); err != nil {
+		return err
+	}
+
+	fmt.Println()
+	return nil
+}
+
+// ──────────────────────────────────────────────
+// destroy:test
+// ──────────────────────────────────────────────
+
+var destroyTestCmd = &cobra.Command{
+	Use:   "destroy:test <Name>",
+	Short: "Remove a test file generated by make:test",
+	Long: destroyLong(
+		"destroy:test",
+		"the gest test file in "+colorCyan+"internal/tests/"+colorReset,
+		"grove destroy:test UserService",
+	),
+	Args: cobra.ExactArgs(1),
+	RunE: runDestroyTest,
+}
+
+func init() {
+	addDestroyFlags(destroyTestCmd)
+}
+
+func runDestroyTest(_ *cobra.Command, args []string) error {
+	name := toPascalCase(args[0])
+
+	printDestroyHeader("test", name)
+
+	if _, err := destroyFile("Test", name, testSpecPath(name), func() ([]byte, error) {
+		return renderTestSpec(name)
+	}); err != nil {
+		return err
+	}
+
+	fmt.Println()
+	return nil
+}
+
+// ──────────────────────────────────────────────
+// Helpers
+// ──────────────────────────────────────────────
+
+func printDestroyHeader(kind, name string) {
+	fmt.Println()
+	fmt.Printf(
+		"  %sDestroying %s%s %s\n",
+		colorGray, kind, colorReset,
+		bold(name),
+	)
+	fmt.Println()
+}
+
+func destroyModel(name string) error {
+	_, err := destroyFile("Model", name, modelPath(name), func() ([]byte, error) {
+		return renderModel(name, nil)
+	})
+	return err
+}
+
+// destroyController removes the controller and, once it is gone, the routes
+// pointing at its handlers. Routes of a kept controller are left alone.
+func destroyController(name string) error {
+	gone, err := destroyFile("Controller", name, controllerPath(name), func() ([]byte, error) {
+		return renderController(name, nil)
+	})
+	if err != nil || !gone {
+		return err
+	}
+	return destroyRoutes(name)
+}
+
+func destroyRequest(name string) error {
+	_, err := destroyFile("DTO", name, requestPath(name), func() ([]byte, error) {
+		return renderRequest(name, nil)
+	})
+	return err
+}
+
+// destroyFile deletes path together with its .grove/generated/ record.
+//
+// The file is considered modified when it differs from the recorded copy or,
+// for files generated before records existed, from pristine() — the default
+// rendering for the name. Modified files are kept unless --force is set.
+//
+// The returned bool reports whether the file is gone afterwards (removed,
+// would be removed in --dry-run, or did not exist in the first place).
+func destroyFile(kind, name, path string, pristine func() ([]byte, error)) (bool, error) {
+	current, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			printKept(kind, name, path, "not found")
+			return true, nil
+		}
+		return false, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	original, err := os.ReadFile(generatedRecordPath(path))
+	if err != nil {
+		if original, err = pristine(); err != nil {
+			return false, err
+		}
+	}
+
+	if !bytes.Equal(current, original) && !destroyForce {
+		printKept(kind, name, path, "modified since generation — use --force")
+		return false, nil
+	}
+
+	if dryRun {
+		printPlanned(kind, name, path, "remove")
+		return true, nil
+	}
+
+	if err := os.Remove(path); err != nil {
+		return false, fmt.Errorf("failed to remove %s: %w", path, err)
+	}
+	_ = os.Remove(generatedRecordPath(path))
+
+	printRemoved(kind, name, path)
+	return true, nil
+}
+
+// destroyRoutes unregisters the CRUD routes for name. A project without a
+// recognisable routes file is not an error — there is nothing to undo.
+func destroyRoutes(name string) error {
+	edit, err := planRoutesRemoval(name)
+	if err != nil || edit.Removed == 0 {
+		return nil
+	}
+
+	if dryRun {
+		return previewFile("Routes", name, edit.Path, edit.Content, "update")
+	}
+
+	if err := writeFile(edit.Path, edit.Content); err != nil {
+		return err
+	}
+
+	printUpdated("Routes", name, edit.Path)
+	return nil
+}
@@ -7,6 +7,13 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var (
+	devProfile   string
+	devLogLevel  string
+	devLogFields []string
+	devLogHide   []string
+)
+
 var devCmd = &cobra.Command{
 	Use:   "dev",
 	Short: "Start the development server with built-in hot reload",
@@ -18,14 +25,30 @@ changes and automatically recompiles + restarts the binary on every save.
 No external tools required — hot reload is built right into Grove.
 
 ` + colorBold + `Output formatting` + colorReset + `
-  Structured JSON logs (slog, zap, zerolog) are automatically parsed and
-  rendered as human-readable coloured lines:
+  Structured logs — JSON, slog/logfmt text, logrus, zap console, Apache
+  access logs and log.Printf lines — are parsed and rendered as
+  human-readable coloured lines:
 
   ` + colorGray + `08:38:28` + colorReset + `  ` + colorGreen + `INF` + colorReset + `  Booting application...
   ` + colorGray + `08:38:28` + colorReset + `  ` + colorRed + `ERR` + colorReset + `  Failed to boot application  ` + colorGray + `error=...` + colorReset + `
 
   Panics are captured and rendered as a styled block with the stack trace.
 
+  ` + colorGray + `log_level` + colorReset + ` hides lines below a level, ` + colorGray + `log_fields` + colorReset + ` shows only the listed keys
+  and ` + colorGray + `log_hide` + colorReset + ` drops keys (or ` + colorGreen + `--log-level` + colorReset + `, ` + colorGreen + `--log-fields` + colorReset + `, ` + colorGreen + `--log-hide` + colorReset + `).
+  Saving grove.toml applies the keys you changed without a restart:
+
+  ` + colorGray + `log_level  = "warn"` + colorReset + `
+  ` + colorGray + `log_fields = ["req_id", "path", "status"]` + colorReset + `
+  ` + colorGray + `log_hide   = ["trace_id"]` + colorReset + `
+
+  Other formats are declared as ` + colorCyan + `[[dev.log_format]]` + colorReset + ` tables, a regular expression
+  with named groups ` + colorGray + `time` + colorReset + `, ` + colorGray + `level` + colorReset + ` and ` + colorGray + `msg` + colorReset + `; other groups become fields:
+
+  ` + colorGray + `[[dev.log_format]]` + colorReset + `
+  ` + colorGray + `name    = "nginx"` + colorReset + `
+  ` + colorGray + `pattern = '^(?P<time>\S+ \S+) \[(?P<level>\w+)\] (?P<msg>.*)$'` + colorReset + `
+
 ` + colorBold + `Startup hints` + colorReset + `
   Grove detects common startup errors and prints an actionable hint:
 
@@ -42,15 +65,103 @@ Configure behaviour via the ` + colorCyan + `[dev]` + colorReset + ` section in
   ` + colorGray + `exclude     = [".grove", "vendor", "node_modules", "tests"]` + colorReset + `
   ` + colorGray + `extensions  = [".go"]` + colorReset + `
   ` + colorGray + `debounce_ms = 50` + colorReset + `
+  ` + colorGray + `pre_build   = ["go generate ./..."]` + colorReset + `
+  ` + colorGray + `post_build  = []` + colorReset + `
+  ` + colorGray + `health_url  = "http://localhost:8080/health"` + colorReset + `
+  ` + colorGray + `health_timeout = "30s"` + colorReset + `
+  ` + colorGray + `ready_window   = "500ms"` + colorReset + `
 
 All fields are optional — sensible defaults are used when ` + colorCyan + `grove.toml` + colorReset + `
 is absent or the ` + colorCyan + `[dev]` + colorReset + ` section is omitted.
 
+` + colorGray + `pre_build` + colorReset + ` commands run before every rebuild and ` + colorGray + `post_build` + colorReset + ` commands between the
+build and the restart; a failing command cancels the restart.
+
+With ` + colorGray + `health_url` + colorReset + ` set, an app counts as restarted once the URL answers 2xx;
+without it, once it has stayed up for ` + colorGray + `ready_window` + colorReset + `.
+
+` + colorBold + `Keyboard` + colorReset + `
+  In a terminal: ` + colorGreen + `r` + colorReset + ` rebuild, ` + colorGreen + `c` + colorReset + ` clear, ` + colorGreen + `l` + colorReset + ` cycle the log level, ` + colorGreen + `t` + colorReset + ` run ` + colorGray + `test_cmd` + colorReset + `,
+  ` + colorGreen + `m` + colorReset + ` run ` + colorGray + `migrate_cmd` + colorReset + `, ` + colorGreen + `q` + colorReset + ` quit, ` + colorGreen + `?` + colorReset + ` help. ` + colorGray + `keyboard = false` + colorReset + ` turns them off.
+
+` + colorBold + `Watch rules` + colorReset + `
+  ` + colorCyan + `[[dev.watch]]` + colorReset + ` tables pick what a change to matching files does instead of
+  a rebuild: ` + colorGray + `restart` + colorReset + `, ` + colorGray + `signal` + colorReset + ` the app, ` + colorGray + `reload` + colorReset + ` the pages, or ` + colorGray + `none` + colorReset + `.
+  An optional command runs first:
+
+  ` + colorGray + `[[dev.watch]]` + colorReset + `
+  ` + colorGray + `pattern = "web/templates/**/*.html"` + colorReset + `
+  ` + colorGray + `action  = "restart"` + colorReset + `
+
+  ` + colorGray + `[[dev.watch]]` + colorReset + `
+  ` + colorGray + `pattern = "**/*.templ"` + colorReset + `
+  ` + colorGray + `command = "templ generate"` + colorReset + `
+  ` + colorGray + `action  = "none"` + colorReset + `
+
+` + colorBold + `Multiple apps` + colorReset + `
+  Declare each binary as an ` + colorCyan + `[[app]]` + colorReset + ` table to supervise them all in parallel,
+  with every output line prefixed by the app's colour-coded name:
+
+  ` + colorGray + `[[app]]` + colorReset + `
+  ` + colorGray + `name = "worker"` + colorReset + `
+  ` + colorGray + `main = "./cmd/worker"` + colorReset + `
+
+  ` + colorGray + `bin` + colorReset + ` and ` + colorGray + `build_cmd` + colorReset + ` default to ` + colorGray + `.grove/tmp/<name>` + colorReset + ` and ` + colorGray + `go build -o <bin> <main>` + colorReset + `.
+
+` + colorBold + `Dev proxy` + colorReset + `
+  With ` + colorGray + `proxy_port` + colorReset + ` set, a reverse proxy forwards to the first app on ` + colorGray + `app_port` + colorReset + `.
+  It holds requests while the app rebuilds and serves the compiler errors
+  when a build fails. Pages it serves reload after every restart, and a
+  changed ` + colorGray + `.css` + colorReset + ` file is swapped in place (` + colorGray + `live_reload = false` + colorReset + ` to disable):
+
+  ` + colorGray + `proxy_port = 3000` + colorReset + `
+  ` + colorGray + `app_port   = 8080` + colorReset + `
+
+` + colorBold + `Environment` + colorReset + `
+  ` + colorGray + `env_file` + colorReset + ` and ` + colorCyan + `[dev.env]` + colorReset + ` set the apps' environment; the files are re-read on
+  every restart and saving one restarts the apps. ` + colorGreen + `--profile` + colorReset + ` adds the env files
+  and variables of a ` + colorCyan + `[dev.profiles.<name>]` + colorReset + ` table:
+
+  ` + colorGray + `[dev]` + colorReset + `
+  ` + colorGray + `env_file = [".env", ".env.local"]` + colorReset + `
+
+  ` + colorGray + `[dev.env]` + colorReset + `
+  ` + colorGray + `LOG_LEVEL = "debug"` + colorReset + `
+
+  ` + colorGray + `[dev.profiles.staging]` + colorReset + `
+  ` + colorGray + `env_file = [".env.staging"]` + colorReset + `
+
 ` + colorGray + `Examples:` + colorReset + `
-  grove dev`,
+  grove dev
+  grove dev --profile staging
+  grove dev --log-level warn --log-hide trace_id`,
+	Args: cobra.NoArgs,
 	RunE: runDev,
 }
 
+func init() {
+	devCmd.Flags().StringVar(
+		&devProfile,
+		"profile", "",
+		"Environment profile from [dev.profiles.<name>] in grove.toml",
+	)
+	devCmd.Flags().StringVar(
+		&devLogLevel,
+		"log-level", "",
+		"Hide structured app output below this level: debug, info, warn or error",
+	)
+	devCmd.Flags().StringSliceVar(
+		&devLogFields,
+		"log-fields", nil,
+		"Only show these extra keys on structured lines, e.g. req_id,path,status",
+	)
+	devCmd.Flags().StringSliceVar(
+		&devLogHide,
+		"log-hide", nil,
+		"Never show these extra keys on structured lines",
+	)
+}
+
 // DevCmd exposes the cobra command so it can be wired from main.go.
 // It is also the entry-point called by tests or external tooling.
 func DevCmd() *cobra.Command { return devCmd }
@@ -62,6 +173,24 @@ func runDev(_ *cobra.Command, _ []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to load grove.toml: %w", err)
 	}
+	if devProfile != "" {
+		if cfg, err = cfg.WithProfile(devProfile); err != nil {
+			return err
+		}
+	}
+
+	if devLogLevel != "" {
+		if err := watcher.ParseLogLevel(devLogLevel); err != nil {
+			return err
+		}
+		cfg.LogLevel = devLogLevel
+	}
+	if len(devLogFields) > 0 {
+		cfg.LogFields = devLogFields
+	}
+	if len(devLogHide) > 0 {
+		cfg.LogHide = devLogHide
+	}
 
 	return watcher.New(cfg).Start()
 }
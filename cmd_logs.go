@@ -0,0 +1,142 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/caiolandgraf/grove/internal/watcher"
+	"github.com/spf13/cobra"
+)
+
+var (
+	logsLevel  string
+	logsGrep   string
+	logsSince  time.Duration
+	logsApp    string
+	logsFollow bool
+	logsFields []string
+	logsHide   []string
+)
+
+var logsCmd = &cobra.Command{
+	Use:   "logs",
+	Short: "Search and replay the output captured by grove dev",
+	Long: bold("logs") + ` replays the app output captured by ` + colorGreen + `grove dev` + colorReset + ` with the same formatting.
+
+Every ` + colorGreen + `grove dev` + colorReset + ` run writes the raw lines of its apps to
+` + colorCyan + `.grove/logs/<run>-<n>.jsonl` + colorReset + `, named after the time the run started. A run
+starts a new file every 10 MB, and only the 20 newest files are kept.
+
+Without ` + colorGreen + `--since` + colorReset + ` the latest run is shown; with it, every line captured in that
+window. ` + colorGreen + `--level` + colorReset + ` hides structured lines below the level, ` + colorGreen + `--grep` + colorReset + ` keeps the lines
+containing the text (ignoring case), and ` + colorGreen + `--follow` + colorReset + ` keeps printing new lines.
+` + colorGreen + `--fields` + colorReset + ` and ` + colorGreen + `--hide` + colorReset + ` pick the extra keys shown. The level and keys default to
+` + colorGray + `log_level` + colorReset + `, ` + colorGray + `log_fields` + colorReset + ` and ` + colorGray + `log_hide` + colorReset + ` in grove.toml.
+
+` + colorGray + `Examples:` + colorReset + `
+  grove logs
+  grove logs --level error
+  grove logs --grep "connection refused" --since 1h
+  grove logs --app worker --follow
+  grove logs --fields req_id,path,status`,
+	Args: cobra.NoArgs,
+	RunE: runLogs,
+}
+
+func init() {
+	logsCmd.Flags().StringVar(
+		&logsLevel,
+		"level", "",
+		"Minimum level of structured lines: debug, info, warn or error",
+	)
+	logsCmd.Flags().StringVar(
+		&logsGrep,
+		"grep", "",
+		"Only show lines containing this text (case-insensitive)",
+	)
+	logsCmd.Flags().DurationVar(
+		&logsSince,
+		"since", 0,
+		"Show the lines captured in this window across runs, e.g. 10m",
+	)
+	logsCmd.Flags().StringVar(
+		&logsApp,
+		"app", "",
+		"Only show the lines of this [[app]]",
+	)
+	logsCmd.Flags().BoolVarP(
+		&logsFollow,
+		"follow", "f", false,
+		"Keep printing lines as grove dev captures them",
+	)
+	logsCmd.Flags().StringSliceVar(
+		&logsFields,
+		"fields", nil,
+		"Only show these extra keys on structured lines",
+	)
+	logsCmd.Flags().StringSliceVar(
+		&logsHide,
+		"hide", nil,
+		"Never show these extra keys on structured lines",
+	)
+}
+
+func runLogs(_ *cobra.Command, _ []string) error {
+	if err := watcher.ParseLogLevel(logsLevel); err != nil {
+		return err
+	}
+
+	cfg, err := watcher.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load grove.toml: %w", err)
+	}
+	q := watcher.LogQuery{
+		Level:   cmp.Or(logsLevel, cfg.LogLevel),
+		Grep:    logsGrep,
+		App:     logsApp,
+		Fields:  cfg.LogFields,
+		Hide:    cfg.LogHide,
+		Formats: cfg.LogFormats,
+	}
+	if len(logsFields) > 0 {
+		q.Fields = logsFields
+	}
+	if len(logsHide) > 0 {
+		q.Hide = logsHide
+	}
+	if logsSince > 0 {
+		q.Since = time.Now().Add(-logsSince)
+	}
+
+	fmt.Println()
+	read, matched, err := watcher.PrintLogs(os.Stdout, watcher.LogDir, q)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", watcher.LogDir, err)
+	}
+	if !logsFollow {
+		switch {
+		case read == 0 && logsSince > 0:
+			fmt.Println(gray(fmt.Sprintf("  No output captured in the last %s.", logsSince)))
+		case read == 0:
+			fmt.Println(gray("  No captured output — run grove dev first."))
+		case matched == 0:
+			fmt.Println(gray("  No matching lines."))
+		}
+		fmt.Println()
+		return nil
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := watcher.FollowLogs(ctx, os.Stdout, watcher.LogDir, q); err != nil {
+		return fmt.Errorf("failed to follow %s: %w", watcher.LogDir, err)
+	}
+	fmt.Println()
+	return nil
+}
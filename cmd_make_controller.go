@@ -6,6 +6,8 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var makeControllerNoRoutes bool
+
 var makeControllerCmd = &cobra.Command{
 	Use:   "make:controller <Name>",
 	Short: "Scaffold a new fuego controller",
@@ -16,18 +18,31 @@ var makeControllerCmd = &cobra.Command{
 The entity name is ` + colorBold + `automatically singularized` + colorReset + ` before generating files,
 so ` + colorCyan + `Posts` + colorReset + ` and ` + colorCyan + `Post` + colorReset + ` both produce the same ` + colorCyan + `PostController` + colorReset + `.
 
+The five CRUD routes are registered automatically in the function that takes a
+` + colorCyan + `*fuego.Server` + colorReset + ` in ` + colorCyan + `internal/routes/` + colorReset + `. Routes that are already wired are left
+untouched; pass ` + colorGreen + `--no-routes` + colorReset + ` to skip this step.
+
 ` + colorGray + `Examples:` + colorReset + `
   grove make:controller Post
   grove make:controller Posts        # same as Post (singularized)
   grove make:controller BlogPost
-  grove make:controller user_profile`,
+  grove make:controller user_profile
+  grove make:controller Post --no-routes`,
 	Args: cobra.ExactArgs(1),
 	RunE: runMakeController,
 }
 
+func init() {
+	makeControllerCmd.Flags().BoolVar(
+		&makeControllerNoRoutes,
+		"no-routes", false,
+		"Do not register the CRUD routes in internal/routes/",
+	)
+	addScaffoldFlags(makeControllerCmd)
+}
+
 func runMakeController(_ *cobra.Command, args []string) error {
 	name := toPascalCase(toSingular(args[0]))
-	snake := toSnakeCase(name)
 
 	fmt.Println()
 	fmt.Printf(
@@ -37,10 +52,16 @@ func runMakeController(_ *cobra.Command, args []string) error {
 	)
 	fmt.Println()
 
-	if err := scaffoldController(name); err != nil {
+	if err := scaffoldController(name, nil); err != nil {
 		return err
 	}
 
+	if !makeControllerNoRoutes {
+		if err := registerRoutes(name); err != nil {
+			return err
+		}
+	}
+
 	fmt.Println()
 	fmt.Println(nextSteps())
 	fmt.Printf(
@@ -52,44 +73,14 @@ func runMakeController(_ *cobra.Command, args []string) error {
 			name,
 		)+"-controller.go"+colorReset,
 	)
-	fmt.Printf(
-		"    %s2.%s Register routes in %s:\n",
-		colorGray, colorReset,
-		colorCyan+"internal/routes/"+colorReset,
-	)
-	fmt.Printf(
-		"             %sfuego.Get(s, \"/%ss/{%s_id}\", controllers.Get%s)\n",
-		colorGray,
-		snake,
-		snake,
-		name+colorReset,
-	)
-	fmt.Printf(
-		"             %sfuego.Get(s, \"/%ss\", controllers.List%ss)\n",
-		colorGray,
-		snake,
-		name+colorReset,
-	)
-	fmt.Printf(
-		"             %sfuego.Post(s, \"/%ss\", controllers.Create%s)\n",
-		colorGray,
-		snake,
-		name+colorReset,
-	)
-	fmt.Printf(
-		"             %sfuego.Put(s, \"/%ss/{%s_id}\", controllers.Update%s)\n",
-		colorGray,
-		snake,
-		snake,
-		name+colorReset,
-	)
-	fmt.Printf(
-		"             %sfuego.Delete(s, \"/%ss/{%s_id}\", controllers.Delete%s)\n",
-		colorGray,
-		snake,
-		snake,
-		name+colorReset,
-	)
+	if makeControllerNoRoutes {
+		fmt.Printf(
+			"    %s2.%s Register routes in %s:\n",
+			colorGray, colorReset,
+			colorCyan+"internal/routes/"+colorReset,
+		)
+		printRouteHint(name)
+	}
 	fmt.Println()
 
 	return nil
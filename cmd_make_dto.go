@@ -25,6 +25,11 @@ so ` + colorCyan + `Posts` + colorReset + ` and ` + colorCyan + `Post` + colorRe
 	RunE: runMakeDto,
 }
 
+func init() {
+	addScaffoldFlags(makeDtoCmd)
+	addScaffoldFlags(makeRequestCmd)
+}
+
 func runMakeDto(_ *cobra.Command, args []string) error {
 	name := toPascalCase(toSingular(args[0]))
 
@@ -36,7 +41,7 @@ func runMakeDto(_ *cobra.Command, args []string) error {
 	)
 	fmt.Println()
 
-	if err := scaffoldRequest(name); err != nil {
+	if err := scaffoldRequest(name, nil); err != nil {
 		return err
 	}
 
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var makeFactoryCmd = &cobra.Command{
+	Use:   "make:factory <Model>",
+	Short: "Scaffold a model factory for tests",
+	Long: bold(
+		"make:factory",
+	) + ` scaffolds a typed factory for a model in ` + colorCyan + `internal/database/factories/` + colorReset + `.
+
+The model's struct in ` + colorCyan + `internal/models/` + colorReset + ` is read to give every column a fake
+default by type and name (emails, URLs, slugs, numbers, times…), unique per
+record. Nullable fields, associations and foreign keys are left as comments
+for you to fill with ` + colorCyan + `With()` + colorReset + `:
+
+  ` + colorGray + `posts := factories.Post().Make(3)` + colorReset + `              build without saving
+  ` + colorGray + `posts, err := factories.Post().Create(3)` + colorReset + `      save via models.Posts()
+  ` + colorGray + `post, err := factories.Post().` + colorReset + `
+  ` + colorGray + `    With(func(p *models.Post) { p.AuthorID = user.ID }).` + colorReset + `
+  ` + colorGray + `    CreateOne()` + colorReset + `
+
+Run ` + colorGreen + `make:factory --force` + colorReset + ` again after changing the model.
+
+` + colorGray + `Examples:` + colorReset + `
+  grove make:factory Post
+  grove make:factory order_items`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMakeFactory,
+}
+
+func init() {
+	addScaffoldFlags(makeFactoryCmd)
+}
+
+func runMakeFactory(_ *cobra.Command, args []string) error {
+	model := toPascalCase(toSingular(toSnakeCase(args[0])))
+
+	fmt.Println()
+	fmt.Printf(
+		"  %sCreating factory%s %s\n",
+		colorGray, colorReset,
+		bold(model+"Factory"),
+	)
+	fmt.Println()
+
+	if err := scaffoldFactory(model); err != nil {
+		return err
+	}
+
+	fmt.Println()
+	fmt.Println(nextSteps())
+	fmt.Printf(
+		"    %s1.%s Review the defaults in %s\n",
+		colorGray, colorReset,
+		colorCyan+factoryPath(model)+colorReset,
+	)
+	fmt.Printf(
+		"    %s2.%s Scaffold a suite that uses it: %s\n",
+		colorGray, colorReset,
+		colorGreen+"grove make:test --model "+model+colorReset,
+	)
+	fmt.Println()
+
+	return nil
+}
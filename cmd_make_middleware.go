@@ -21,6 +21,10 @@ var makeMiddlewareCmd = &cobra.Command{
 	RunE: runMakeMiddleware,
 }
 
+func init() {
+	addScaffoldFlags(makeMiddlewareCmd)
+}
+
 func runMakeMiddleware(_ *cobra.Command, args []string) error {
 	name := toPascalCase(args[0])
 	kebab := toKebabCase(name)
@@ -4,12 +4,17 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 
 	"github.com/spf13/cobra"
 )
 
-var makeMigrationEnv string
+var (
+	makeMigrationEnv      string
+	makeMigrationOffline  bool
+	makeMigrationBaseline bool
+)
 
 var makeMigrationCmd = &cobra.Command{
 	Use:   "make:migration <name>",
@@ -23,11 +28,27 @@ var makeMigrationCmd = &cobra.Command{
 	then run this command — Atlas will produce the exact SQL diff between your
 	updated struct and the current database schema.
 
+	With ` + colorGreen + `--offline` + colorReset + ` (or ` + colorCyan + `offline = true` + colorReset + ` under ` + colorCyan + `[database]` + colorReset + ` in grove.toml) no
+	database or Atlas binary is needed: grove reads the structs and their
+	` + colorCyan + `gorm:"…"` + colorReset + ` tags itself, diffs them against the snapshot in
+	` + colorCyan + `.grove/schema.json` + colorReset + ` and writes the SQL plus an updated ` + colorCyan + `atlas.sum` + colorReset + `.
+	The SQL dialect comes from ` + colorCyan + `[database] dialect` + colorReset + ` (postgres, mysql, sqlite).
+
+	Projects that already have migrations record their current models once
+	with ` + colorGreen + `--baseline` + colorReset + ` before the first offline migration.
+
 ` + colorGray + `Examples:` + colorReset + `
   grove make:migration add_posts_table
   grove make:migration add_email_to_users
-  grove make:migration create_orders_table --env dev`,
-	Args: cobra.ExactArgs(1),
+  grove make:migration create_orders_table --env dev
+  grove make:migration add_orders_table --offline
+  grove make:migration --offline --baseline`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if makeMigrationBaseline {
+			return cobra.MaximumNArgs(1)(cmd, args)
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
 	RunE: runMakeMigration,
 }
 
@@ -37,14 +58,39 @@ func init() {
 		"env", "local",
 		"Atlas environment to use (local, dev, production)",
 	)
+	makeMigrationCmd.Flags().BoolVar(
+		&makeMigrationOffline,
+		"offline", false,
+		"Diff the GORM models without Atlas or a database",
+	)
+	makeMigrationCmd.Flags().BoolVar(
+		&makeMigrationBaseline,
+		"baseline", false,
+		"Record the current models as the offline snapshot without writing a migration",
+	)
 }
 
 func runMakeMigration(cmd *cobra.Command, args []string) error {
+	cfg, err := loadProjectConfig()
+	if err != nil {
+		return err
+	}
+
+	if makeMigrationBaseline {
+		return runSchemaBaseline(cfg.Database)
+	}
+
 	name := args[0]
 	// Normalize: spaces → underscores, lowercase
 	name = strings.ToLower(strings.ReplaceAll(name, " ", "_"))
 	name = strings.ReplaceAll(name, "-", "_")
 
+	// The native driver exists for machines without the Atlas CLI, so it
+	// always generates offline.
+	if makeMigrationOffline || cfg.Database.Offline || cfg.Database.Native() {
+		return runMakeMigrationOffline(name, cfg.Database)
+	}
+
 	fmt.Println()
 	fmt.Printf(
 		"  %sGenerating migration%s %s %s\n",
@@ -54,6 +100,15 @@ func runMakeMigration(cmd *cobra.Command, args []string) error {
 	)
 	fmt.Println()
 
+	if dryRun {
+		fmt.Printf(
+			"  %s  %s\n\n",
+			badge(colorBgBlue, "WOULD RUN"),
+			gray("atlas migrate diff "+name+" --env "+makeMigrationEnv),
+		)
+		return nil
+	}
+
 	// Check atlas is available
 	if _, err := exec.LookPath("atlas"); err != nil {
 		return fmt.Errorf(
@@ -74,7 +129,7 @@ func runMakeMigration(cmd *cobra.Command, args []string) error {
 	c.Stderr = aw
 	c.Stdin = os.Stdin
 
-	err := c.Run()
+	err = c.Run()
 	aw.Flush()
 
 	if err != nil {
@@ -87,6 +142,13 @@ func runMakeMigration(cmd *cobra.Command, args []string) error {
 			name,
 		) + " created in " + colorCyan + "migrations/" + colorReset,
 	))
+	printMigrationNextSteps()
+
+	return nil
+}
+
+// printMigrationNextSteps prints the hints shown after a migration is created.
+func printMigrationNextSteps() {
 	fmt.Println()
 	fmt.Println(nextSteps())
 	fmt.Printf(
@@ -100,6 +162,186 @@ func runMakeMigration(cmd *cobra.Command, args []string) error {
 		colorGreen+"grove migrate"+colorReset,
 	)
 	fmt.Println()
+}
+
+// ──────────────────────────────────────────────
+// Offline generation
+// ──────────────────────────────────────────────
+
+// runMakeMigrationOffline diffs the GORM models against the recorded
+// snapshot and writes the resulting SQL without touching a database.
+func runMakeMigrationOffline(name string, db databaseConfig) error {
+	fmt.Println()
+	fmt.Printf(
+		"  %sGenerating migration%s %s %s\n",
+		colorGray, colorReset,
+		bold(name),
+		gray("(offline, "+db.Dialect+")"),
+	)
+	fmt.Println()
+
+	desired, err := loadModelSchema(modelsDir, db.Dialect)
+	if err != nil {
+		return err
+	}
+
+	current, found, err := loadSchemaSnapshot()
+	if err != nil {
+		return err
+	}
+	if !found {
+		existing, err := migrationFiles(migrationsDir)
+		if err != nil {
+			return err
+		}
+		if len(existing) > 0 {
+			return fmt.Errorf(
+				"%s has migrations but no schema snapshot exists yet\n\n  Record the current models first with: %s",
+				migrationsDir,
+				colorGreen+"grove make:migration --offline --baseline"+colorReset,
+			)
+		}
+	} else if current.Dialect != "" && current.Dialect != db.Dialect {
+		return fmt.Errorf(
+			"%s was recorded for %s but [database] dialect is %s",
+			schemaSnapshotPath, current.Dialect, db.Dialect,
+		)
+	}
+
+	changes, err := diffSchemas(db.Dialect, current, desired)
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		fmt.Println(info("The models match " + schemaSnapshotPath + " — nothing to migrate."))
+		fmt.Println()
+		return nil
+	}
+
+	path := filepath.Join(migrationsDir, newMigrationName(name))
+	content := renderMigration(changes)
+
+	// The reverse diff becomes the down migration used by the native
+	// driver's migrate:rollback. Some changes cannot be reversed offline.
+	downPath := filepath.Join(downMigrationsDir, filepath.Base(path))
+	downChanges, downErr := diffSchemas(db.Dialect, desired, current)
+
+	snapshot, err := marshalSchemaSnapshot(desired)
+	if err != nil {
+		return err
+	}
+
+	if dryRun {
+		if err := previewFile("Migration", name, path, content, "create"); err != nil {
+			return err
+		}
+		if downErr == nil {
+			if err := previewFile("Down", name, downPath, renderMigration(downChanges), "create"); err != nil {
+				return err
+			}
+		}
+		printPlanned("Checksum", atlasSumFile, filepath.Join(migrationsDir, atlasSumFile), "update")
+		printPlanned("Snapshot", "schema", schemaSnapshotPath, "update")
+		fmt.Println()
+		return nil
+	}
+
+	if err := writeFile(path, content); err != nil {
+		return err
+	}
+	printCreated("Migration", name, path)
+
+	if downErr == nil {
+		if err := writeFile(downPath, renderMigration(downChanges)); err != nil {
+			return err
+		}
+		printCreated("Down", name, downPath)
+	}
+
+	if err := writeAtlasSum(migrationsDir); err != nil {
+		return err
+	}
+	printUpdated("Checksum", atlasSumFile, filepath.Join(migrationsDir, atlasSumFile))
+
+	if err := writeFile(schemaSnapshotPath, snapshot); err != nil {
+		return err
+	}
+	if found {
+		printUpdated("Snapshot", "schema", schemaSnapshotPath)
+	} else {
+		printCreated("Snapshot", "schema", schemaSnapshotPath)
+	}
+
+	if downErr != nil {
+		fmt.Println()
+		fmt.Println(warn("No down migration written: " + downErr.Error()))
+	}
+
+	for _, c := range changes {
+		if c.Destructive {
+			fmt.Println()
+			fmt.Println(warn(
+				"The migration drops tables or columns — review it, renames show up as drop + add.",
+			))
+			break
+		}
+	}
+
+	fmt.Println()
+	fmt.Println(done(
+		"Migration " + bold(
+			name,
+		) + " created in " + colorCyan + "migrations/" + colorReset,
+	))
+	printMigrationNextSteps()
+
+	return nil
+}
+
+// runSchemaBaseline records the schema of the current models as the offline
+// snapshot, for projects whose existing migrations were generated by Atlas.
+func runSchemaBaseline(db databaseConfig) error {
+	fmt.Println()
+	fmt.Printf(
+		"  %sRecording schema baseline%s %s\n",
+		colorGray, colorReset,
+		gray("("+db.Dialect+")"),
+	)
+	fmt.Println()
+
+	desired, err := loadModelSchema(modelsDir, db.Dialect)
+	if err != nil {
+		return err
+	}
+	snapshot, err := marshalSchemaSnapshot(desired)
+	if err != nil {
+		return err
+	}
+
+	action := "create"
+	if fileExists(schemaSnapshotPath) {
+		action = "update"
+	}
+
+	if dryRun {
+		return previewFile("Snapshot", "schema", schemaSnapshotPath, snapshot, action)
+	}
+
+	if err := writeFile(schemaSnapshotPath, snapshot); err != nil {
+		return err
+	}
+	if action == "update" {
+		printUpdated("Snapshot", "schema", schemaSnapshotPath)
+	} else {
+		printCreated("Snapshot", "schema", schemaSnapshotPath)
+	}
+
+	fmt.Println()
+	fmt.Println(done(fmt.Sprintf(
+		"Recorded %d table(s) — the next %s migration diffs against them.",
+		len(desired.Tables), colorGreen+"--offline"+colorReset,
+	)))
+	fmt.Println()
 
 	return nil
 }
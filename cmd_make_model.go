@@ -11,10 +11,11 @@ var (
 	makeModelWithController bool
 	makeModelWithDTO        bool
 	makeModelResource       bool
+	makeModelNoRoutes       bool
 )
 
 var makeModelCmd = &cobra.Command{
-	Use:   "make:model <Name>",
+	Use:   "make:model <Name> [field:type...]",
 	Short: "Scaffold a new GORM model",
 	Long: bold(
 		"make:model",
@@ -27,12 +28,26 @@ so you can pass the name in any form:
   ` + colorGray + `Posts` + colorReset + `       → model ` + colorCyan + `Post` + colorReset + `, table ` + colorCyan + `posts` + colorReset + `
   ` + colorGray + `order_items` + colorReset + ` → model ` + colorCyan + `OrderItem` + colorReset + `, table ` + colorCyan + `order_items` + colorReset + `
 
+Fields can be declared inline as ` + colorCyan + `name:type` + colorReset + ` pairs after the name. Struct
+fields, GORM tags and JSON tags are generated for you, and the same fields flow
+into the DTO and the controller mapper when ` + colorGreen + `-d` + colorReset + ` / ` + colorGreen + `-r` + colorReset + ` is used:
+
+  ` + colorGray + `title:string` + colorReset + `            plain column (` + colorGray + `text int int64 uint float decimal bool` + colorReset + `
+                          ` + colorGray + `time date uuid json` + colorReset + ` are also supported)
+  ` + colorGray + `published_at:time?` + colorReset + `      trailing ` + colorCyan + `?` + colorReset + ` makes the column nullable (pointer)
+  ` + colorGray + `email:string:unique` + colorReset + `     add a unique index (` + colorCyan + `:index` + colorReset + ` for a plain index)
+  ` + colorGray + `author:belongs_to:User` + colorReset + `  foreign key ` + colorCyan + `AuthorID` + colorReset + ` + association ` + colorCyan + `Author *User` + colorReset + `
+  ` + colorGray + `comments:has_many` + colorReset + `       association ` + colorCyan + `Comments []Comment` + colorReset + `
+
 Combine flags to scaffold additional layers in one shot:
 
   ` + colorGreen + `-c` + colorReset + `  also scaffold a fuego controller
   ` + colorGreen + `-d` + colorReset + `  also scaffold a DTO request/response file
   ` + colorGreen + `-r` + colorReset + `  full resource — shorthand for ` + colorGreen + `-c -d` + colorReset + ` combined
 
+With ` + colorGreen + `-c` + colorReset + ` the CRUD routes are also registered in ` + colorCyan + `internal/routes/` + colorReset + `
+unless ` + colorGreen + `--no-routes` + colorReset + ` is given.
+
 ` + colorYellow + `Migration workflow:` + colorReset + `
   Migrations are NOT generated automatically. After adding fields to your model,
   run ` + colorGreen + `grove make:migration <name>` + colorReset + ` to generate the SQL diff via Atlas.
@@ -48,8 +63,9 @@ Combine flags to scaffold additional layers in one shot:
   grove make:model Post -r
   grove make:model BlogPost -c
   grove make:model BlogPost -d
-  grove make:model order_items --resource`,
-	Args: cobra.ExactArgs(1),
+  grove make:model order_items --resource
+  grove make:model Post title:string body:text published_at:time? author:belongs_to:User -r`,
+	Args: cobra.MinimumNArgs(1),
 	RunE: runMakeModel,
 }
 
@@ -69,6 +85,12 @@ func init() {
 		"resource", "r", false,
 		"Full resource — shorthand for -c -d",
 	)
+	makeModelCmd.Flags().BoolVar(
+		&makeModelNoRoutes,
+		"no-routes", false,
+		"Do not register the CRUD routes in internal/routes/ (with -c)",
+	)
+	addScaffoldFlags(makeModelCmd)
 }
 
 func runMakeModel(_ *cobra.Command, args []string) error {
@@ -76,6 +98,11 @@ func runMakeModel(_ *cobra.Command, args []string) error {
 	snake := toSnakeCase(name)
 	tableName := toPlural(snake)
 
+	fields, err := parseFieldSpecs(args[1:])
+	if err != nil {
+		return err
+	}
+
 	// -r expands to -c -d
 	if makeModelResource {
 		makeModelWithController = true
@@ -87,20 +114,25 @@ func runMakeModel(_ *cobra.Command, args []string) error {
 	fmt.Println()
 
 	// ── model ────────────────────────────────────────────────────────────────
-	if err := scaffoldModel(name); err != nil {
+	if err := scaffoldModel(name, fields); err != nil {
 		return err
 	}
 
 	// ── controller ───────────────────────────────────────────────────────────
 	if makeModelWithController {
-		if err := scaffoldController(name); err != nil {
+		if err := scaffoldController(name, fields); err != nil {
 			return err
 		}
+		if !makeModelNoRoutes {
+			if err := registerRoutes(name); err != nil {
+				return err
+			}
+		}
 	}
 
 	// ── DTO ──────────────────────────────────────────────────────────────────
 	if makeModelWithDTO {
-		if err := scaffoldRequest(name); err != nil {
+		if err := scaffoldRequest(name, fields); err != nil {
 			return err
 		}
 	}
@@ -111,14 +143,22 @@ func runMakeModel(_ *cobra.Command, args []string) error {
 
 	step := 1
 
-	fmt.Printf(
-		"    %s%d.%s Add your fields to the model in %s\n",
-		colorGray, step, colorReset,
-		colorCyan+"internal/models/"+snake+".go"+colorReset,
-	)
+	if len(fields) > 0 {
+		fmt.Printf(
+			"    %s%d.%s Review the generated fields in %s\n",
+			colorGray, step, colorReset,
+			colorCyan+"internal/models/"+snake+".go"+colorReset,
+		)
+	} else {
+		fmt.Printf(
+			"    %s%d.%s Add your fields to the model in %s\n",
+			colorGray, step, colorReset,
+			colorCyan+"internal/models/"+snake+".go"+colorReset,
+		)
+	}
 	step++
 
-	if makeModelWithDTO {
+	if makeModelWithDTO && len(fields) == 0 {
 		fmt.Printf(
 			"    %s%d.%s Fill in request/response fields in %s\n",
 			colorGray, step, colorReset,
@@ -145,7 +185,7 @@ func runMakeModel(_ *cobra.Command, args []string) error {
 	)
 	step++
 
-	if makeModelWithController {
+	if makeModelWithController && makeModelNoRoutes {
 		fmt.Printf(
 			"    %s%d.%s Register routes in %s\n",
 			colorGray, step, colorReset,
@@ -7,15 +7,19 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var makeResourceNoRoutes bool
+
 var makeResourceCmd = &cobra.Command{
-	Use:   "make:resource <Name>",
+	Use:   "make:resource <Name> [field:type...]",
 	Short: "Scaffold model + controller + DTO at once",
 	Long: bold(
 		"make:resource",
 	) + ` scaffolds a model, controller and DTO file in one shot.
 
 This is equivalent to running ` + colorCyan + `grove make:model <Name> -r` + colorReset + `.
-Every file respects the ` + colorCyan + `SKIPPED` + colorReset + ` rule — existing files are never overwritten.
+Every file respects the ` + colorCyan + `SKIPPED` + colorReset + ` rule — existing files are never overwritten
+unless ` + colorGreen + `--force` + colorReset + ` (overwrite) or ` + colorGreen + `--merge` + colorReset + ` (three-way merge with your edits) is given.
+The CRUD routes are registered in ` + colorCyan + `internal/routes/` + colorReset + ` unless ` + colorGreen + `--no-routes` + colorReset + ` is given.
 
 The entity name is ` + colorBold + `automatically singularized` + colorReset + ` before generating files,
 so you can pass the name in any form:
@@ -24,6 +28,9 @@ so you can pass the name in any form:
   ` + colorGray + `Posts` + colorReset + `       → model ` + colorCyan + `Post` + colorReset + `, table ` + colorCyan + `posts` + colorReset + `
   ` + colorGray + `order_items` + colorReset + ` → model ` + colorCyan + `OrderItem` + colorReset + `, table ` + colorCyan + `order_items` + colorReset + `
 
+Inline field definitions (` + colorCyan + `title:string body:text author:belongs_to:User` + colorReset + `)
+are accepted after the name — see ` + colorGreen + `grove make:model --help` + colorReset + ` for the syntax.
+
 ` + colorYellow + `Migration workflow:` + colorReset + `
   Migrations are NOT generated automatically. After adding fields to your model,
   run ` + colorGreen + `grove make:migration <name>` + colorReset + ` to generate the SQL diff via Atlas.
@@ -35,17 +42,32 @@ so you can pass the name in any form:
   grove make:resource Post
   grove make:resource Posts
   grove make:resource BlogPost
-  grove make:resource order_items`,
-	Args: cobra.ExactArgs(1),
+  grove make:resource order_items
+  grove make:resource Post title:string body:text published_at:time?`,
+	Args: cobra.MinimumNArgs(1),
 	RunE: runMakeResource,
 }
 
+func init() {
+	makeResourceCmd.Flags().BoolVar(
+		&makeResourceNoRoutes,
+		"no-routes", false,
+		"Do not register the CRUD routes in internal/routes/",
+	)
+	addScaffoldFlags(makeResourceCmd)
+}
+
 func runMakeResource(_ *cobra.Command, args []string) error {
 	name := toPascalCase(toSingular(args[0]))
 	snake := toSnakeCase(name)
 	tableName := toPlural(snake)
 	migrationName := "create_" + strings.ToLower(tableName) + "_table"
 
+	fields, err := parseFieldSpecs(args[1:])
+	if err != nil {
+		return err
+	}
+
 	fmt.Println()
 	fmt.Printf(
 		"  %sScaffolding resource%s %s\n",
@@ -54,47 +76,70 @@ func runMakeResource(_ *cobra.Command, args []string) error {
 	)
 	fmt.Println()
 
-	if err := scaffoldModel(name); err != nil {
+	if err := scaffoldModel(name, fields); err != nil {
 		return err
 	}
 
-	if err := scaffoldController(name); err != nil {
+	if err := scaffoldController(name, fields); err != nil {
 		return err
 	}
 
-	if err := scaffoldRequest(name); err != nil {
+	if err := scaffoldRequest(name, fields); err != nil {
 		return err
 	}
 
+	if !makeResourceNoRoutes {
+		if err := registerRoutes(name); err != nil {
+			return err
+		}
+	}
+
 	// ── next steps ───────────────────────────────────────────────────────────
 	fmt.Println()
 	fmt.Println(nextSteps())
 
+	step := 1
+
+	if len(fields) > 0 {
+		fmt.Printf(
+			"    %s%d.%s Review the generated fields in %s\n",
+			colorGray, step, colorReset,
+			colorCyan+"internal/models/"+snake+".go"+colorReset,
+		)
+		step++
+	} else {
+		fmt.Printf(
+			"    %s%d.%s Add fields to the model in %s\n",
+			colorGray, step, colorReset,
+			colorCyan+"internal/models/"+snake+".go"+colorReset,
+		)
+		step++
+		fmt.Printf(
+			"    %s%d.%s Fill in request/response fields in %s\n",
+			colorGray, step, colorReset,
+			colorCyan+"internal/dto/"+toKebabCase(name)+"-dto.go"+colorReset,
+		)
+		step++
+	}
 	fmt.Printf(
-		"    %s1.%s Add fields to the model in %s\n",
-		colorGray, colorReset,
-		colorCyan+"internal/models/"+snake+".go"+colorReset,
-	)
-	fmt.Printf(
-		"    %s2.%s Fill in request/response fields in %s\n",
-		colorGray, colorReset,
-		colorCyan+"internal/dto/"+toKebabCase(name)+"-dto.go"+colorReset,
-	)
-	fmt.Printf(
-		"    %s3.%s Run %s to generate the migration\n",
-		colorGray, colorReset,
+		"    %s%d.%s Run %s to generate the migration\n",
+		colorGray, step, colorReset,
 		colorGreen+"grove make:migration "+migrationName+colorReset,
 	)
+	step++
 	fmt.Printf(
-		"    %s4.%s Run %s to apply it\n",
-		colorGray, colorReset,
+		"    %s%d.%s Run %s to apply it\n",
+		colorGray, step, colorReset,
 		colorGreen+"grove migrate"+colorReset,
 	)
-	fmt.Printf(
-		"    %s5.%s Register routes in %s\n",
-		colorGray, colorReset,
-		colorCyan+"internal/routes/"+colorReset,
-	)
+	step++
+	if makeResourceNoRoutes {
+		fmt.Printf(
+			"    %s%d.%s Register routes in %s\n",
+			colorGray, step, colorReset,
+			colorCyan+"internal/routes/"+colorReset,
+		)
+	}
 	fmt.Println()
 
 	return nil
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var makeSeederCmd = &cobra.Command{
+	Use:   "make:seeder <Name>",
+	Short: "Scaffold a database seeder",
+	Long: bold(
+		"make:seeder",
+	) + ` scaffolds a seeder in ` + colorCyan + `internal/database/seeders/` + colorReset + ` that inserts
+records through the model's repository (` + colorCyan + `models.<Name>s()` + colorReset + `).
+
+The seeder is appended to ` + colorCyan + `seeders.All` + colorReset + `, the ordered list ` + colorGreen + `grove db:seed` + colorReset + ` runs.
+The first seeder also creates that registry and ` + colorCyan + `cmd/seed/main.go` + colorReset + `.
+
+The name is singularized and a trailing "Seeder" is optional:
+
+  ` + colorGray + `User` + colorReset + `        → ` + colorCyan + `UserSeeder` + colorReset + ` seeding ` + colorCyan + `models.User` + colorReset + `
+  ` + colorGray + `UserSeeder` + colorReset + `  → ` + colorCyan + `UserSeeder` + colorReset + `
+  ` + colorGray + `blog_posts` + colorReset + `  → ` + colorCyan + `BlogPostSeeder` + colorReset + `
+
+` + colorGray + `Examples:` + colorReset + `
+  grove make:seeder User
+  grove make:seeder BlogPostSeeder`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMakeSeeder,
+}
+
+func init() {
+	addScaffoldFlags(makeSeederCmd)
+}
+
+func runMakeSeeder(_ *cobra.Command, args []string) error {
+	model := seederModel(args[0])
+
+	fmt.Println()
+	fmt.Printf(
+		"  %sCreating seeder%s %s\n",
+		colorGray, colorReset,
+		bold(model+"Seeder"),
+	)
+	fmt.Println()
+
+	if !fileExists(modelPath(model)) {
+		fmt.Println(warn("Model " + model + " not found at " + modelPath(model) + " — the seeder will not compile until it exists."))
+		fmt.Println()
+	}
+
+	if err := scaffoldSeeder(model); err != nil {
+		return err
+	}
+	if err := registerSeeder(model); err != nil {
+		return err
+	}
+	if err := scaffoldSeedMain(); err != nil {
+		return err
+	}
+
+	fmt.Println()
+	fmt.Println(nextSteps())
+	fmt.Printf(
+		"    %s1.%s Add the records to insert in %s\n",
+		colorGray, colorReset,
+		colorCyan+seederPath(model)+colorReset,
+	)
+	fmt.Printf(
+		"    %s2.%s Run %s\n",
+		colorGray, colorReset,
+		colorGreen+"grove db:seed"+colorReset,
+	)
+	fmt.Println()
+
+	return nil
+}
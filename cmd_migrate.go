@@ -1,9 +1,11 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 
 	"github.com/spf13/cobra"
 )
@@ -44,14 +46,25 @@ func init() {
 }
 
 func runMigrate(cmd *cobra.Command, args []string) error {
+	db, err := loadDatabaseConfig()
+	if err != nil {
+		return err
+	}
+
 	fmt.Println()
 	fmt.Printf(
 		"  %sRunning migrations%s %s\n",
 		colorGray, colorReset,
-		gray("(atlas migrate apply --env "+migrateEnv+")"),
+		gray("("+engineName(db)+" migrate apply --env "+migrateEnv+")"),
 	)
 	fmt.Println()
 
+	if db.Native() {
+		return withNativeMigrator(db, migrateEnv, func(ctx context.Context, m *nativeMigrator) error {
+			return m.Apply(ctx, 0)
+		})
+	}
+
 	return runAtlas("apply pending migrations",
 		"migrate", "apply",
 		"--env", migrateEnv,
@@ -73,6 +86,11 @@ var migrateRollbackCmd = &cobra.Command{
 	Long: bold("migrate:rollback") + ` rolls back the last applied migration
 using Atlas migrate down.
 
+You are asked to type the environment name before anything is reverted.
+Protected environments reject the command: production, unless grove.toml
+sets ` + colorCyan + `protected = false` + colorReset + `, and any marked ` + colorCyan + `protected = true` + colorReset + `.
+Every attempt is recorded in .grove/audit.log.
+
 ` + colorGray + `Examples:` + colorReset + `
   grove migrate:rollback
   grove migrate:rollback --amount 3
@@ -94,6 +112,16 @@ func init() {
 }
 
 func runMigrateRollback(cmd *cobra.Command, args []string) error {
+	db, err := loadDatabaseConfig()
+	if err != nil {
+		return err
+	}
+
+	ok, err := guardDestructive(db, "migrate:rollback", migrateRollbackEnv, false)
+	if err != nil || !ok {
+		return err
+	}
+
 	amount := fmt.Sprintf("%d", migrateRollbackAmount)
 
 	fmt.Println()
@@ -101,10 +129,16 @@ func runMigrateRollback(cmd *cobra.Command, args []string) error {
 		"  %sRolling back%s %s %s\n",
 		colorGray, colorReset,
 		bold(amount),
-		gray("migration(s) (atlas migrate down --env "+migrateRollbackEnv+")"),
+		gray("migration(s) ("+engineName(db)+" migrate down --env "+migrateRollbackEnv+")"),
 	)
 	fmt.Println()
 
+	if db.Native() {
+		return withNativeMigrator(db, migrateRollbackEnv, func(ctx context.Context, m *nativeMigrator) error {
+			return m.Down(ctx, migrateRollbackAmount)
+		})
+	}
+
 	return runAtlas("rollback migration",
 		"migrate", "down",
 		"--amount", amount,
@@ -116,7 +150,10 @@ func runMigrateRollback(cmd *cobra.Command, args []string) error {
 // migrate:status
 // ──────────────────────────────────────────────
 
-var migrateStatusEnv string
+var (
+	migrateStatusEnv  string
+	migrateStatusJSON bool
+)
 
 var migrateStatusCmd = &cobra.Command{
 	Use:   "migrate:status",
@@ -124,9 +161,14 @@ var migrateStatusCmd = &cobra.Command{
 	Long: bold("migrate:status") + ` shows which migrations have been applied
 and which are still pending using Atlas migrate status.
 
+With --json it prints a machine-readable report instead: current and next
+version, applied files with timestamps, pending files and checksum
+mismatches. "up_to_date" is true only when nothing is pending or broken.
+
 ` + colorGray + `Examples:` + colorReset + `
   grove migrate:status
-  grove migrate:status --env dev`,
+  grove migrate:status --env dev
+  grove migrate:status --env production --json | jq -e .up_to_date`,
 	RunE: runMigrateStatus,
 }
 
@@ -136,23 +178,71 @@ func init() {
 		"env", "local",
 		"Atlas environment to use (local, dev, production)",
 	)
+	migrateStatusCmd.Flags().BoolVar(
+		&migrateStatusJSON,
+		"json", false,
+		"Print the status as JSON",
+	)
 }
 
 func runMigrateStatus(cmd *cobra.Command, args []string) error {
+	db, err := loadDatabaseConfig()
+	if err != nil {
+		return err
+	}
+
+	if migrateStatusJSON {
+		return runMigrateStatusJSON(db)
+	}
+
 	fmt.Println()
 	fmt.Printf(
 		"  %sChecking migration status%s %s\n",
 		colorGray, colorReset,
-		gray("(atlas migrate status --env "+migrateStatusEnv+")"),
+		gray("("+engineName(db)+" migrate status --env "+migrateStatusEnv+")"),
 	)
 	fmt.Println()
 
+	if db.Native() {
+		return withNativeMigrator(db, migrateStatusEnv, func(ctx context.Context, m *nativeMigrator) error {
+			st, err := m.Status(ctx)
+			if err != nil {
+				return err
+			}
+			m.PrintStatus(st)
+			return nil
+		})
+	}
+
 	return runAtlas("check migration status",
 		"migrate", "status",
 		"--env", migrateStatusEnv,
 	)
 }
 
+// runMigrateStatusJSON prints the status report of migrateStatusEnv. Stdout
+// carries nothing but the JSON document.
+func runMigrateStatusJSON(db databaseConfig) error {
+	var (
+		revs map[string]revision
+		err  error
+	)
+	if db.Native() {
+		revs, err = nativeRevisions(db, migrateStatusEnv)
+	} else {
+		revs, err = atlasRevisions(migrateStatusEnv)
+	}
+	if err != nil {
+		return err
+	}
+
+	report, err := buildStatusReport(engineName(db), migrateStatusEnv, revs)
+	if err != nil {
+		return err
+	}
+	return printStatusReport(report)
+}
+
 // ──────────────────────────────────────────────
 // migrate:fresh
 // ──────────────────────────────────────────────
@@ -160,6 +250,7 @@ func runMigrateStatus(cmd *cobra.Command, args []string) error {
 var (
 	migrateFreshEnv   string
 	migrateFreshForce bool
+	migrateFreshSeed  bool
 )
 
 var migrateFreshCmd = &cobra.Command{
@@ -169,9 +260,18 @@ var migrateFreshCmd = &cobra.Command{
 migration from scratch. ` + colorRed + `This is a destructive operation.` + colorReset + `
 Only use it on development databases.
 
+You are asked to type the environment name before anything is dropped;
+--force skips the question for the local environment only. Protected
+environments reject the command: production, unless grove.toml sets
+` + colorCyan + `protected = false` + colorReset + `, and any marked ` + colorCyan + `protected = true` + colorReset + `.
+Every attempt is recorded in .grove/audit.log.
+
+With --seed the seeders run afterwards (see ` + colorGreen + `grove db:seed` + colorReset + `).
+
 ` + colorGray + `Examples:` + colorReset + `
   grove migrate:fresh
   grove migrate:fresh --force
+  grove migrate:fresh --seed
   grove migrate:fresh --env dev`,
 	RunE: runMigrateFresh,
 }
@@ -185,36 +285,31 @@ func init() {
 	migrateFreshCmd.Flags().BoolVar(
 		&migrateFreshForce,
 		"force", false,
-		"Skip the confirmation prompt",
+		"Skip the confirmation prompt (local environment only)",
+	)
+	migrateFreshCmd.Flags().BoolVar(
+		&migrateFreshSeed,
+		"seed", false,
+		"Run the database seeders afterwards",
 	)
 }
 
 func runMigrateFresh(cmd *cobra.Command, args []string) error {
-	if !migrateFreshForce {
-		fmt.Println()
-		fmt.Printf(
-			"  %s WARNING %s  This will %sdrop all tables%s and re-run every migration.\n",
-			colorBgYellow,
-			colorReset,
-			colorRed,
-			colorReset,
-		)
-		fmt.Printf(
-			"  Environment: %s\n", bold(migrateFreshEnv),
-		)
-		fmt.Println()
-		fmt.Print("  Are you sure? [y/N] ")
+	db, err := loadDatabaseConfig()
+	if err != nil {
+		return err
+	}
 
-		var answer string
-		if _, err := fmt.Scanln(
-			&answer,
-		); err != nil ||
-			(answer != "y" && answer != "Y") {
-			fmt.Println()
-			fmt.Println(warn("Aborted."))
-			fmt.Println()
-			return nil
+	ok, err := guardDestructive(db, "migrate:fresh", migrateFreshEnv, migrateFreshForce)
+	if err != nil || !ok {
+		return err
+	}
+
+	if db.Native() {
+		if err := runMigrateFreshNative(db); err != nil {
+			return err
 		}
+		return seedAfterFresh(db)
 	}
 
 	fmt.Println()
@@ -255,7 +350,53 @@ func runMigrateFresh(cmd *cobra.Command, args []string) error {
 	)
 	fmt.Println()
 
-	return nil
+	return seedAfterFresh(db)
+}
+
+// seedAfterFresh runs the seeders when migrate:fresh was given --seed.
+func seedAfterFresh(db databaseConfig) error {
+	if !migrateFreshSeed {
+		return nil
+	}
+	return runSeeders(db, migrateFreshEnv, nil)
+}
+
+// runMigrateFreshNative is migrate:fresh on the native engine: both steps
+// share one connection.
+func runMigrateFreshNative(db databaseConfig) error {
+	return withNativeMigrator(db, migrateFreshEnv, func(ctx context.Context, m *nativeMigrator) error {
+		fmt.Println()
+		fmt.Printf(
+			"  %sDropping all tables%s %s\n",
+			colorGray, colorReset,
+			gray("(native schema clean --env "+migrateFreshEnv+")"),
+		)
+		fmt.Println()
+
+		if err := m.Clean(ctx); err != nil {
+			return err
+		}
+
+		fmt.Println()
+		fmt.Printf(
+			"  %sRe-applying all migrations%s %s\n",
+			colorGray, colorReset,
+			gray("(native migrate apply --env "+migrateFreshEnv+")"),
+		)
+		fmt.Println()
+
+		if err := m.Apply(ctx, 0); err != nil {
+			return err
+		}
+
+		fmt.Println()
+		fmt.Printf(
+			"  %s DONE %s  Database wiped and migrations re-applied.\n",
+			colorBgGreen, colorReset,
+		)
+		fmt.Println()
+		return nil
+	})
 }
 
 // ──────────────────────────────────────────────
@@ -274,17 +415,82 @@ migrations directory. Run this if you edited a migration file manually.
 }
 
 func runMigrateHash(cmd *cobra.Command, args []string) error {
+	db, err := loadDatabaseConfig()
+	if err != nil {
+		return err
+	}
+
 	fmt.Println()
 	fmt.Printf(
 		"  %sRehashing migrations directory%s %s\n",
 		colorGray, colorReset,
-		gray("(atlas migrate hash)"),
+		gray("("+engineName(db)+" migrate hash)"),
 	)
 	fmt.Println()
 
+	if db.Native() {
+		path := filepath.Join(migrationsDir, atlasSumFile)
+		if dryRun {
+			printPlanned("Checksum", atlasSumFile, path, "update")
+			fmt.Println()
+			return nil
+		}
+		if err := writeAtlasSum(migrationsDir); err != nil {
+			return err
+		}
+		printUpdated("Checksum", atlasSumFile, path)
+		fmt.Println()
+		return nil
+	}
+
 	return runAtlas("hash migrations directory", "migrate", "hash")
 }
 
+// ──────────────────────────────────────────────
+// Engine selection
+// ──────────────────────────────────────────────
+
+// loadDatabaseConfig returns the [database] section of grove.toml.
+func loadDatabaseConfig() (databaseConfig, error) {
+	cfg, err := loadProjectConfig()
+	if err != nil {
+		return databaseConfig{}, err
+	}
+	return cfg.Database, nil
+}
+
+// engineName is shown in the command headers: "atlas" or "native".
+func engineName(db databaseConfig) string {
+	if db.Native() {
+		return "native"
+	}
+	return "atlas"
+}
+
+// withNativeMigrator connects the native engine to the database of env and
+// runs fn with its output rendered through atlasOutputWriter.
+func withNativeMigrator(
+	db databaseConfig,
+	env string,
+	fn func(ctx context.Context, m *nativeMigrator) error,
+) error {
+	url, err := db.EnvURL(env)
+	if err != nil {
+		return err
+	}
+
+	aw := newAtlasOutputWriter(os.Stdout)
+	defer aw.Flush()
+
+	m, err := openNativeMigrator(url, aw)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	return fn(context.Background(), m)
+}
+
 // ──────────────────────────────────────────────
 // Shared atlas runner
 // ──────────────────────────────────────────────
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var schemaDumpOutput string
+
+var schemaDumpCmd = &cobra.Command{
+	Use:   "schema:dump",
+	Short: "Print the SQL schema described by the GORM models",
+	Long: bold("schema:dump") + ` reads the structs in ` + colorCyan + `internal/models/` + colorReset + ` and prints the
+CREATE statements GORM would run for them, in the dialect configured under
+` + colorCyan + `[database] dialect` + colorReset + ` in grove.toml. No database is needed.
+
+Point Atlas at the file to use it as the desired state, e.g. in atlas.hcl:
+
+  ` + colorCyan + `src = "file://schema.sql"` + colorReset + `
+
+` + colorGray + `Examples:` + colorReset + `
+  grove schema:dump
+  grove schema:dump -o schema.sql`,
+	Args: cobra.NoArgs,
+	RunE: runSchemaDump,
+}
+
+func init() {
+	schemaDumpCmd.Flags().StringVarP(
+		&schemaDumpOutput,
+		"output", "o", "",
+		"Write the schema to a file instead of stdout",
+	)
+}
+
+func runSchemaDump(_ *cobra.Command, _ []string) error {
+	cfg, err := loadProjectConfig()
+	if err != nil {
+		return err
+	}
+
+	desired, err := loadModelSchema(modelsDir, cfg.Database.Dialect)
+	if err != nil {
+		return err
+	}
+
+	changes, err := diffSchemas(cfg.Database.Dialect, &dbSchema{}, desired)
+	if err != nil {
+		return err
+	}
+	content := renderMigration(changes)
+
+	if schemaDumpOutput == "" {
+		_, err := os.Stdout.Write(content)
+		return err
+	}
+
+	fmt.Println()
+	if dryRun {
+		action := "create"
+		if fileExists(schemaDumpOutput) {
+			action = "update"
+		}
+		if err := previewFile("Schema", cfg.Database.Dialect, schemaDumpOutput, content, action); err != nil {
+			return err
+		}
+		fmt.Println()
+		return nil
+	}
+
+	if err := writeFile(schemaDumpOutput, content); err != nil {
+		return err
+	}
+	printCreated("Schema", cfg.Database.Dialect, schemaDumpOutput)
+	fmt.Println()
+
+	return nil
+}
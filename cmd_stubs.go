@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var stubsPublishForce bool
+
+var stubsPublishCmd = &cobra.Command{
+	Use:   "stubs:publish [stub...]",
+	Short: "Copy the generator stubs into .grove/stubs for customisation",
+	Long: bold(
+		"stubs:publish",
+	) + ` copies the built-in generator templates into ` + colorCyan + `.grove/stubs/` + colorReset + `.
+
+Every ` + colorGreen + `make:*` + colorReset + ` command prefers a project-local stub over the built-in one,
+so once published you can edit them to match your house conventions —
+different ID types, a different error envelope, another router, etc.
+
+Available stubs: ` + colorCyan + strings.Join(stubNames, ", ") + colorReset + `
+
+Existing files are never overwritten unless ` + colorGreen + `--force` + colorReset + ` is given.
+
+` + colorGray + `Examples:` + colorReset + `
+  grove stubs:publish
+  grove stubs:publish controller request
+  grove stubs:publish model --force`,
+	ValidArgs: stubNames,
+	RunE:      runStubsPublish,
+}
+
+func init() {
+	stubsPublishCmd.Flags().BoolVarP(
+		&stubsPublishForce,
+		"force", "f", false,
+		"Overwrite stubs that were already published",
+	)
+}
+
+func runStubsPublish(_ *cobra.Command, args []string) error {
+	names := args
+	if len(names) == 0 {
+		names = stubNames
+	}
+
+	for _, name := range names {
+		name = strings.TrimSuffix(name, ".stub")
+		if _, ok := embeddedStubs[name]; !ok {
+			return fmt.Errorf(
+				"unknown stub %q (available: %s)",
+				name, strings.Join(stubNames, ", "),
+			)
+		}
+	}
+
+	fmt.Println()
+	fmt.Printf(
+		"  %sPublishing stubs%s %s\n",
+		colorGray, colorReset,
+		gray("(→ "+projectStubsDir+")"),
+	)
+	fmt.Println()
+
+	for _, name := range names {
+		name = strings.TrimSuffix(name, ".stub")
+		destPath := filepath.Join(projectStubsDir, name+".stub")
+
+		exists := fileExists(destPath)
+
+		if dryRun {
+			action := "create"
+			if exists {
+				action = "skip"
+				if stubsPublishForce {
+					action = "update"
+				}
+			}
+			if err := previewFile("Stub", name, destPath, []byte(embeddedStubs[name]), action); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if exists && !stubsPublishForce {
+			printSkipped("Stub", name, destPath)
+			continue
+		}
+
+		if err := writeFile(destPath, []byte(embeddedStubs[name])); err != nil {
+			return err
+		}
+
+		printCreated("Stub", name, destPath)
+	}
+
+	fmt.Println()
+	fmt.Println(nextSteps())
+	fmt.Printf(
+		"    %s1.%s Edit the templates in %s\n",
+		colorGray, colorReset,
+		colorCyan+projectStubsDir+"/"+colorReset,
+	)
+	fmt.Printf(
+		"    %s2.%s Commit them so the whole team generates the same code\n",
+		colorGray, colorReset,
+	)
+	fmt.Printf(
+		"    %s3.%s Delete a stub to fall back to the built-in version\n",
+		colorGray, colorReset,
+	)
+	fmt.Println()
+
+	return nil
+}
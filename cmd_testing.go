@@ -16,6 +16,8 @@ import (
 // make:test
 // ──────────────────────────────────────────────
 
+var makeTestModel string
+
 var makeTestCmd = &cobra.Command{
 	Use:   "make:test <Name>",
 	Short: "Scaffold a new gest test file",
@@ -27,19 +29,47 @@ The generated file follows the ` + colorCyan + `gest v2` + colorReset + ` conven
 ` + colorCyan + `*_test.go` + colorReset + ` file with a ` + colorCyan + `func Test<Name>(t *testing.T)` + colorReset + ` entry point
 that calls ` + colorCyan + `s.Run(t)` + colorReset + ` — fully compatible with ` + colorGray + `go test` + colorReset + `.
 
+With ` + colorGreen + `--model` + colorReset + ` the suite builds and saves records through the model's
+factory (see ` + colorGreen + `make:factory` + colorReset + `), which is generated first when missing. The
+name defaults to the model's.
+
 On the first call, gest is added to the project's ` + colorCyan + `go.mod` + colorReset + ` automatically
 via ` + colorGray + `go get` + colorReset + `.
 
 ` + colorGray + `Examples:` + colorReset + `
   grove make:test User
   grove make:test AuthService
-  grove make:test order_calculations`,
-	Args: cobra.ExactArgs(1),
+  grove make:test order_calculations
+  grove make:test --model Post
+  grove make:test PostAPI --model Post`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if makeTestModel != "" {
+			return cobra.MaximumNArgs(1)(cmd, args)
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
 	RunE: runMakeTest,
 }
 
+func init() {
+	addScaffoldFlags(makeTestCmd)
+	makeTestCmd.Flags().StringVar(
+		&makeTestModel,
+		"model", "",
+		"Scaffold a suite that uses the model's factory",
+	)
+}
+
 func runMakeTest(_ *cobra.Command, args []string) error {
-	name := toPascalCase(args[0])
+	model := ""
+	if makeTestModel != "" {
+		model = toPascalCase(toSingular(toSnakeCase(makeTestModel)))
+	}
+
+	name := model
+	if len(args) > 0 {
+		name = toPascalCase(args[0])
+	}
 
 	fmt.Println()
 	fmt.Printf(
@@ -49,7 +79,13 @@ func runMakeTest(_ *cobra.Command, args []string) error {
 	)
 	fmt.Println()
 
-	if err := scaffoldTestSpec(name); err != nil {
+	if model != "" && !fileExists(factoryPath(model)) {
+		if err := scaffoldFactory(model); err != nil {
+			return err
+		}
+	}
+
+	if err := scaffoldTestSpec(name, model); err != nil {
 		return err
 	}
 
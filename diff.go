@@ -0,0 +1,260 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ──────────────────────────────────────────────
+// Line diff
+// ──────────────────────────────────────────────
+
+// diffOp is a single line-level edit produced by diffLines.
+type diffOp struct {
+	Kind byte   // ' ' unchanged, '-' removed from a, '+' added from b
+	Line string // line content without the trailing newline
+	A, B int    // 0-based line index in a / b (-1 when not applicable)
+}
+
+// splitLines splits text into lines without their trailing newlines. A final
+// newline does not produce an empty trailing line.
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	lines := strings.Split(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// diffLines returns the shortest edit script turning a into b, computed from
+// the longest common subsequence of lines. Generated files are small, so the
+// O(n·m) table is not a concern.
+func diffLines(a, b []string) []diffOp {
+	n, m := len(a), len(b)
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var ops []diffOp
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, diffOp{Kind: ' ', Line: a[i], A: i, B: j})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, diffOp{Kind: '-', Line: a[i], A: i, B: -1})
+			i++
+		default:
+			ops = append(ops, diffOp{Kind: '+', Line: b[j], A: -1, B: j})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		ops = append(ops, diffOp{Kind: '-', Line: a[i], A: i, B: -1})
+	}
+	for ; j < m; j++ {
+		ops = append(ops, diffOp{Kind: '+', Line: b[j], A: -1, B: j})
+	}
+	return ops
+}
+
+// unifiedDiff renders the difference between a and b in unified diff format
+// with three lines of context. It returns "" when both are identical.
+func unifiedDiff(aName, bName string, a, b []byte) string {
+	ops := diffLines(splitLines(string(a)), splitLines(string(b)))
+
+	const context = 3
+
+	// Collect the index ranges of ops that belong to each hunk.
+	type span struct{ start, end int }
+	var hunks []span
+	for k, op := range ops {
+		if op.Kind == ' ' {
+			continue
+		}
+		start, end := max(k-context, 0), min(k+context+1, len(ops))
+		if len(hunks) > 0 && start <= hunks[len(hunks)-1].end {
+			hunks[len(hunks)-1].end = end
+			continue
+		}
+		hunks = append(hunks, span{start, end})
+	}
+	if len(hunks) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", aName, bName)
+	for _, h := range hunks {
+		aStart, bStart, aLen, bLen := -1, -1, 0, 0
+		for _, op := range ops[h.start:h.end] {
+			if op.Kind != '+' {
+				if aStart < 0 {
+					aStart = op.A
+				}
+				aLen++
+			}
+			if op.Kind != '-' {
+				if bStart < 0 {
+					bStart = op.B
+				}
+				bLen++
+			}
+		}
+		fmt.Fprintf(&sb, "@@ -%s +%s @@\n", hunkRange(aStart, aLen), hunkRange(bStart, bLen))
+		for _, op := range ops[h.start:h.end] {
+			sb.WriteByte(op.Kind)
+			sb.WriteString(op.Line)
+			sb.WriteByte('\n')
+		}
+	}
+	return sb.String()
+}
+
+// hunkRange formats a 0-based start and length as a unified diff range.
+func hunkRange(start, length int) string {
+	if length == 0 {
+		return fmt.Sprintf("%d,0", max(start, 0))
+	}
+	if length == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, length)
+}
+
+// printDiff prints a unified diff with Grove's colours: additions green,
+// removals red, hunk headers cyan.
+func printDiff(diff string) {
+	for i, line := range splitLines(diff) {
+		switch {
+		case i < 2:
+			fmt.Printf("    %s%s%s\n", colorBold, line, colorReset)
+		case strings.HasPrefix(line, "@@"):
+			fmt.Printf("    %s%s%s\n", colorCyan, line, colorReset)
+		case strings.HasPrefix(line, "+"):
+			fmt.Printf("    %s%s%s\n", colorGreen, line, colorReset)
+		case strings.HasPrefix(line, "-"):
+			fmt.Printf("    %s%s%s\n", colorRed, line, colorReset)
+		default:
+			fmt.Printf("    %s%s%s\n", colorGray, line, colorReset)
+		}
+	}
+}
+
+// ──────────────────────────────────────────────
+// Three-way merge
+// ──────────────────────────────────────────────
+
+// Conflict marker labels used by merge3.
+const (
+	conflictOurs   = "<<<<<<< yours"
+	conflictSep    = "======="
+	conflictTheirs = ">>>>>>> generated"
+)
+
+// merge3 merges the user's edits (ours) and a freshly generated file
+// (theirs), both derived from base — the content grove generated last time.
+//
+// Lines that only one side changed are taken from that side. Regions that
+// both sides changed differently are emitted between conflict markers, and
+// the number of such regions is returned.
+func merge3(base, ours, theirs []byte) ([]byte, int) {
+	b := splitLines(string(base))
+	o := splitLines(string(ours))
+	t := splitLines(string(theirs))
+
+	matchO := baseMatches(b, o)
+	matchT := baseMatches(b, t)
+
+	var out []string
+	conflicts := 0
+	i, x, y := 0, 0, 0 // positions in base, ours, theirs
+
+	for i < len(b) || x < len(o) || y < len(t) {
+		// Fast path: base line kept verbatim on both sides.
+		if i < len(b) && matchO[i] == x && matchT[i] == y {
+			out = append(out, b[i])
+			i, x, y = i+1, x+1, y+1
+			continue
+		}
+
+		// Find the next base line that both sides kept — the end of this
+		// unstable chunk.
+		k := i
+		for k < len(b) && (matchO[k] < x || matchT[k] < y) {
+			k++
+		}
+		endO, endT := len(o), len(t)
+		if k < len(b) {
+			endO, endT = matchO[k], matchT[k]
+		}
+
+		baseChunk, oursChunk, theirsChunk := b[i:k], o[x:endO], t[y:endT]
+
+		switch {
+		case equalLines(oursChunk, baseChunk):
+			out = append(out, theirsChunk...)
+		case equalLines(theirsChunk, baseChunk), equalLines(oursChunk, theirsChunk):
+			out = append(out, oursChunk...)
+		default:
+			conflicts++
+			out = append(out, conflictOurs)
+			out = append(out, oursChunk...)
+			out = append(out, conflictSep)
+			out = append(out, theirsChunk...)
+			out = append(out, conflictTheirs)
+		}
+
+		i, x, y = k, endO, endT
+	}
+
+	if len(out) == 0 {
+		return nil, conflicts
+	}
+	return []byte(strings.Join(out, "\n") + "\n"), conflicts
+}
+
+// baseMatches returns, for every line of base, the index of the matching
+// line in other according to diffLines, or -1 when the line was removed.
+func baseMatches(base, other []string) []int {
+	match := make([]int, len(base))
+	for i := range match {
+		match[i] = -1
+	}
+	for _, op := range diffLines(base, other) {
+		if op.Kind == ' ' {
+			match[op.A] = op.B
+		}
+	}
+	return match
+}
+
+// equalLines reports whether a and b hold the same lines.
+func equalLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ──────────────────────────────────────────────
+// Factories
+// ──────────────────────────────────────────────
+//
+// make:factory reads a model's struct from internal/models/ and generates a
+// typed builder in internal/database/factories/ with a fake default for every
+// column it knows how to fill. Fields it cannot fill sensibly — nullable
+// pointers, associations and their foreign keys, unknown types — are listed
+// as comments so the gap is visible in the generated code.
+
+// factoriesDir is the package holding the project's model factories.
+var factoriesDir = filepath.Join("internal", "database", "factories")
+
+// factoryPath returns internal/database/factories/<snake>_factory.go.
+func factoryPath(name string) string {
+	return filepath.Join(factoriesDir, toSnakeCase(name)+"_factory.go")
+}
+
+// factoryField is one field of the definition() literal. Fields without a
+// Value are rendered as a comment carrying Note.
+type factoryField struct {
+	Name  string
+	Value string
+	Note  string
+}
+
+// skippedFactoryFields are filled in by the database or GORM.
+var skippedFactoryFields = map[string]bool{
+	"ID":        true,
+	"CreatedAt": true,
+	"UpdatedAt": true,
+	"DeletedAt": true,
+}
+
+// modelFactoryFields returns the factory fields of model, in declaration
+// order.
+func modelFactoryFields(model string) ([]factoryField, error) {
+	b, err := parseModels(modelsDir, "postgres")
+	if err != nil {
+		return nil, err
+	}
+	st, ok := b.structs[model]
+	if !ok {
+		return nil, fmt.Errorf(
+			"model %s not found in %s\n\n  Create it first: %s",
+			model, modelsDir+"/",
+			colorGreen+"grove make:model "+model+colorReset,
+		)
+	}
+
+	// Association fields, so their foreign keys can be recognised.
+	assocs := map[string]string{}
+	for _, field := range st.Fields.List {
+		elem := strings.TrimLeft(exprString(field.Type), "*[]")
+		if _, ok := b.structs[elem]; ok {
+			for _, n := range field.Names {
+				assocs[n.Name] = elem
+			}
+		}
+	}
+
+	var fields []factoryField
+	for _, field := range st.Fields.List {
+		tag := gormTag(field)
+		if _, ok := tag["-"]; ok {
+			continue
+		}
+		if _, ok := tag["primarykey"]; ok {
+			continue
+		}
+		if _, ok := tag["autocreatetime"]; ok {
+			continue
+		}
+		if _, ok := tag["autoupdatetime"]; ok {
+			continue
+		}
+		goType := exprString(field.Type)
+
+		for _, ident := range field.Names {
+			name := ident.Name
+			if !ident.IsExported() || skippedFactoryFields[name] {
+				continue
+			}
+			f := factoryField{Name: name}
+
+			switch {
+			case assocs[name] != "":
+				f.Note = "association with " + assocs[name] + " — set it with With()"
+			case strings.HasSuffix(name, "ID") && assocs[strings.TrimSuffix(name, "ID")] != "":
+				f.Note = "references " + assocs[strings.TrimSuffix(name, "ID")] + " — set it with With()"
+			case strings.HasPrefix(goType, "*"):
+				f.Note = "nullable, left nil"
+			default:
+				f.Value = fakeValue(name, b.underlying(goType), goType, tag)
+				if f.Value == "" {
+					f.Note = "no fake value for " + goType + " — set it with With()"
+				}
+			}
+			fields = append(fields, f)
+		}
+	}
+	return fields, nil
+}
+
+// fakeValue returns a Go expression producing a fake value for a field of
+// the given underlying type. seq is the record's sequence number, so values
+// of unique columns never collide. named is the declared type, used to
+// convert back when the model uses a named type such as "type Status string".
+func fakeValue(name, underlying, named string, tag map[string]string) string {
+	column := toDBName(name)
+	conv := func(expr string) string {
+		if named == underlying {
+			return expr
+		}
+		if !strings.Contains(named, ".") {
+			named = "models." + named
+		}
+		return named + "(" + expr + ")"
+	}
+
+	switch underlying {
+	case "string":
+		typ := strings.ToLower(tag["type"])
+		switch {
+		case typ == "uuid":
+			return conv(`fmt.Sprintf("00000000-0000-4000-8000-%012d", seq)`)
+		case typ == "json" || typ == "jsonb":
+			return conv(`"{}"`)
+		case strings.Contains(column, "email"):
+			return conv(`fmt.Sprintf("user%d@example.com", seq)`)
+		case strings.HasSuffix(column, "url") || strings.Contains(column, "link"):
+			return conv(`fmt.Sprintf("https://example.com/` + toKebabCase(name) + `/%d", seq)`)
+		case strings.Contains(column, "phone"):
+			return conv(`fmt.Sprintf("+1555%07d", seq)`)
+		case strings.Contains(column, "slug"):
+			return conv(`fmt.Sprintf("` + toKebabCase(name) + `-%d", seq)`)
+		}
+		label := toWords(name)
+		label = strings.ToUpper(label[:1]) + label[1:]
+		return conv(`fmt.Sprintf("` + label + ` %d", seq)`)
+
+	case "int":
+		return conv("seq")
+	case "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
+		return conv(underlying + "(seq)")
+	case "float32", "float64":
+		return conv(underlying + "(seq) + 0.5")
+	case "bool":
+		return conv("seq%2 == 0")
+	case "time.Time":
+		return "time.Now().UTC()"
+	}
+	return ""
+}
+
+// scaffoldFactory renders the factory for model from its struct definition.
+func scaffoldFactory(model string) error {
+	content, err := renderFactory(model)
+	if err != nil {
+		return err
+	}
+
+	return emitFile("Factory", model+"Factory", factoryPath(model), content)
+}
+
+func renderFactory(model string) ([]byte, error) {
+	fields, err := modelFactoryFields(model)
+	if err != nil {
+		return nil, err
+	}
+
+	imports := []string{"sync/atomic"}
+	for _, f := range fields {
+		if strings.Contains(f.Value, "fmt.") && !containsString(imports, "fmt") {
+			imports = append(imports, "fmt")
+		}
+		if strings.Contains(f.Value, "time.") && !containsString(imports, "time") {
+			imports = append(imports, "time")
+		}
+	}
+	sort.Strings(imports)
+
+	data := struct {
+		Name    string
+		Var     string
+		Module  string
+		Imports []string
+		Fields  []factoryField
+	}{
+		Name:    model,
+		Var:     toLowerFirst(model),
+		Module:  getModuleName(),
+		Imports: imports,
+		Fields:  fields,
+	}
+
+	return renderStub(factoryStub, "factory", data)
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,314 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ──────────────────────────────────────────────
+// Inline field definitions
+// ──────────────────────────────────────────────
+
+// modelField describes a single struct field generated from an inline
+// "name:type" spec passed to make:model / make:resource, e.g.:
+//
+//	title:string
+//	published_at:time?
+//	author:belongs_to:User
+//
+// A belongs_to spec expands into two fields: the foreign key column
+// (AuthorID) and the association itself (Author *User). Association fields
+// carry a non-empty Relation and are only rendered in the model; DTOs and the
+// controller mappers work with plain columns.
+type modelField struct {
+	// Name is the exported Go field name, e.g. "PublishedAt".
+	Name string
+
+	// Column is the snake_case column name, also used as the JSON key.
+	Column string
+
+	// Type is the Go type used in the model, e.g. "string" or "*time.Time".
+	Type string
+
+	// GormTag is the content of the gorm:"…" struct tag (may be empty).
+	GormTag string
+
+	// Nullable is true when the spec ended with "?" — the model field is a
+	// pointer and the column accepts NULL.
+	Nullable bool
+
+	// Relation is "belongs_to" or "has_many" for association fields and empty
+	// for plain columns.
+	Relation string
+}
+
+// JSONTag returns the content of the json:"…" struct tag for the model.
+func (f modelField) JSONTag() string {
+	if f.Relation != "" || f.Nullable {
+		return f.Column + ",omitempty"
+	}
+	return f.Column
+}
+
+// UpdateType returns the Go type used in the Update<Name>Request DTO. Every
+// field is a pointer so that omitted keys leave the stored value untouched.
+func (f modelField) UpdateType() string {
+	if strings.HasPrefix(f.Type, "*") {
+		return f.Type
+	}
+	return "*" + f.Type
+}
+
+// CreateTag returns the full struct tag body used in Create<Name>Request.
+// Non-nullable fields are marked as required for fuego's validator, except
+// booleans where false is a legitimate value.
+func (f modelField) CreateTag() string {
+	if f.Nullable {
+		return `json:"` + f.Column + `,omitempty"`
+	}
+	if f.Type == "bool" {
+		return `json:"` + f.Column + `"`
+	}
+	return `json:"` + f.Column + `" validate:"required"`
+}
+
+// IsTime reports whether the field needs the "time" import.
+func (f modelField) IsTime() bool {
+	return strings.TrimPrefix(f.Type, "*") == "time.Time"
+}
+
+// fieldType describes how a spec type maps to Go and GORM.
+type fieldType struct {
+	goType   string
+	gormType string
+}
+
+// fieldTypes maps every supported spec type to its Go type and optional GORM
+// column type. Aliases point at the same definition.
+var fieldTypes = map[string]fieldType{
+	"string":    {goType: "string"},
+	"text":      {goType: "string", gormType: "text"},
+	"int":       {goType: "int"},
+	"int64":     {goType: "int64"},
+	"bigint":    {goType: "int64"},
+	"uint":      {goType: "uint"},
+	"float":     {goType: "float64"},
+	"decimal":   {goType: "float64", gormType: "numeric"},
+	"bool":      {goType: "bool"},
+	"time":      {goType: "time.Time"},
+	"datetime":  {goType: "time.Time"},
+	"timestamp": {goType: "time.Time"},
+	"date":      {goType: "time.Time", gormType: "date"},
+	"uuid":      {goType: "string", gormType: "uuid"},
+	"json":      {goType: "string", gormType: "jsonb"},
+}
+
+// reservedColumns are already part of every generated model skeleton.
+var reservedColumns = map[string]bool{
+	"id":         true,
+	"created_at": true,
+	"updated_at": true,
+	"deleted_at": true,
+}
+
+// parseFieldSpecs parses the inline field definitions that follow the entity
+// name on the command line. The accepted grammar is:
+//
+//	<name>:<type>[?][:unique|:index]...
+//	<name>:belongs_to[?][:<Model>]
+//	<name>:has_many[:<Model>]
+//
+// Run "grove make:model --help" for the list of supported types.
+func parseFieldSpecs(specs []string) ([]modelField, error) {
+	var fields []modelField
+	seen := map[string]bool{}
+
+	add := func(f modelField) error {
+		if reservedColumns[f.Column] {
+			return fmt.Errorf(
+				"field %q is already part of every model — remove it from the field list",
+				f.Column,
+			)
+		}
+		if seen[f.Name] {
+			return fmt.Errorf("field %q is defined more than once", f.Column)
+		}
+		seen[f.Name] = true
+		fields = append(fields, f)
+		return nil
+	}
+
+	for _, spec := range specs {
+		parts := strings.Split(spec, ":")
+		if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf(
+				"invalid field %q — expected %s",
+				spec, colorCyan+"name:type"+colorReset,
+			)
+		}
+
+		column := toSnakeCase(parts[0])
+		typ := strings.ToLower(parts[1])
+		nullable := strings.HasSuffix(typ, "?")
+		typ = strings.TrimSuffix(typ, "?")
+		modifiers := parts[2:]
+
+		switch typ {
+		case "belongs_to":
+			target := toPascalCase(column)
+			if len(modifiers) > 0 && modifiers[0] != "" {
+				target = toPascalCase(modifiers[0])
+			}
+			fk := modelField{
+				Name:     goFieldName(column + "_id"),
+				Column:   column + "_id",
+				Type:     "string",
+				GormTag:  "type:uuid;not null;index",
+				Nullable: nullable,
+			}
+			if nullable {
+				fk.Type = "*string"
+				fk.GormTag = "type:uuid;index"
+			}
+			if err := add(fk); err != nil {
+				return nil, err
+			}
+			if err := add(modelField{
+				Name:     goFieldName(column),
+				Column:   column,
+				Type:     "*" + target,
+				GormTag:  "foreignKey:" + fk.Name,
+				Relation: "belongs_to",
+			}); err != nil {
+				return nil, err
+			}
+
+		case "has_many":
+			target := toPascalCase(toSingular(column))
+			if len(modifiers) > 0 && modifiers[0] != "" {
+				target = toPascalCase(modifiers[0])
+			}
+			if err := add(modelField{
+				Name:     goFieldName(column),
+				Column:   column,
+				Type:     "[]" + target,
+				Relation: "has_many",
+			}); err != nil {
+				return nil, err
+			}
+
+		default:
+			ft, ok := fieldTypes[typ]
+			if !ok {
+				return nil, fmt.Errorf(
+					"invalid field %q — unknown type %q (supported: %s)",
+					spec, typ, strings.Join(supportedFieldTypes(), ", "),
+				)
+			}
+
+			var tag []string
+			if ft.gormType != "" {
+				tag = append(tag, "type:"+ft.gormType)
+			}
+			if !nullable {
+				tag = append(tag, "not null")
+			}
+			for _, m := range modifiers {
+				switch strings.ToLower(m) {
+				case "unique":
+					tag = append(tag, "uniqueIndex")
+				case "index":
+					tag = append(tag, "index")
+				default:
+					return nil, fmt.Errorf(
+						"invalid field %q — unknown modifier %q (supported: unique, index)",
+						spec, m,
+					)
+				}
+			}
+
+			goType := ft.goType
+			if nullable {
+				goType = "*" + goType
+			}
+
+			if err := add(modelField{
+				Name:     goFieldName(column),
+				Column:   column,
+				Type:     goType,
+				GormTag:  strings.Join(tag, ";"),
+				Nullable: nullable,
+			}); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return fields, nil
+}
+
+// columnFields returns the fields that map to real table columns, i.e. every
+// field except associations. These are the fields exposed through DTOs.
+func columnFields(fields []modelField) []modelField {
+	var out []modelField
+	for _, f := range fields {
+		if f.Relation == "" {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// fieldsNeedTime reports whether any of the fields uses time.Time.
+func fieldsNeedTime(fields []modelField) bool {
+	for _, f := range fields {
+		if f.IsTime() {
+			return true
+		}
+	}
+	return false
+}
+
+// supportedFieldTypes returns the sorted list of accepted spec types,
+// including the relation keywords.
+func supportedFieldTypes() []string {
+	out := make([]string, 0, len(fieldTypes)+2)
+	for k := range fieldTypes {
+		out = append(out, k)
+	}
+	out = append(out, "belongs_to", "has_many")
+	sort.Strings(out)
+	return out
+}
+
+// goFieldName converts a snake_case column into an exported Go field name,
+// upper-casing common initialisms so the result passes golint:
+//
+//	"author_id"  → "AuthorID"
+//	"avatar_url" → "AvatarURL"
+func goFieldName(column string) string {
+	initialisms := map[string]string{
+		"id":   "ID",
+		"url":  "URL",
+		"uri":  "URI",
+		"uuid": "UUID",
+		"api":  "API",
+		"ip":   "IP",
+		"http": "HTTP",
+		"json": "JSON",
+		"sql":  "SQL",
+	}
+	var b strings.Builder
+	for _, p := range strings.Split(column, "_") {
+		if p == "" {
+			continue
+		}
+		if up, ok := initialisms[p]; ok {
+			b.WriteString(up)
+			continue
+		}
+		b.WriteString(toPascalCase(p))
+	}
+	return b.String()
+}
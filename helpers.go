@@ -212,7 +212,8 @@ func (aw *atlasOutputWriter) writeLine(line string) {
 	}
 
 	// ── "Migrating to version …" header ──────────────────────────────────────
-	if strings.HasPrefix(trimmed, "Migrating to version ") {
+	if strings.HasPrefix(trimmed, "Migrating to version ") ||
+		strings.HasPrefix(trimmed, "Migrating down from version ") {
 		fmt.Fprintf(aw.w, "\n  %s%s%s\n", colorBold, trimmed, colorReset)
 		return
 	}
@@ -228,6 +229,17 @@ func (aw *atlasOutputWriter) writeLine(line string) {
 		return
 	}
 
+	// ── "-- reverting applied version …" section opener (migrate down) ───────
+	if strings.HasPrefix(trimmed, "-- reverting applied version ") {
+		version := strings.TrimPrefix(trimmed, "-- reverting applied version ")
+		fmt.Fprintf(aw.w,
+			"\n  %s%s%s  %s%s%s\n",
+			colorBgYellow, " ROLLBACK ", colorReset,
+			colorBold, version, colorReset,
+		)
+		return
+	}
+
 	// ── "-- ok (…)" success line ──────────────────────────────────────────────
 	if strings.HasPrefix(trimmed, "-- ok") {
 		elapsed := ""
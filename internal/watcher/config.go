@@ -3,12 +3,15 @@ package watcher
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/BurntSushi/toml"
 )
 
 // Config holds all settings for the dev watcher.
-// Every field maps 1-to-1 with the [dev] section in grove.toml.
+// Every field but Apps maps 1-to-1 with the [dev] section in grove.toml.
 type Config struct {
 	// Root is the working directory from which build commands are run.
 	Root string `toml:"root"`
@@ -34,8 +37,120 @@ type Config struct {
 	// DebounceMs is the debounce window in milliseconds. Burst saves within
 	// this window are collapsed into a single rebuild.
 	DebounceMs int `toml:"debounce_ms"`
+
+	// HealthURL is polled after each restart; the app counts as up once it
+	// answers 2xx. It applies to the default app; declared [[app]] tables
+	// set their own.
+	HealthURL string `toml:"health_url"`
+
+	// HealthTimeout bounds the health check, e.g. "30s".
+	HealthTimeout time.Duration `toml:"health_timeout"`
+
+	// ReadyWindow is how long an app without a health URL must stay up
+	// after a restart to count as started rather than crashed, e.g. "500ms".
+	ReadyWindow time.Duration `toml:"ready_window"`
+
+	// ProxyPort enables the dev proxy on this port in front of the first
+	// app, which must listen on AppPort.
+	ProxyPort int `toml:"proxy_port"`
+
+	// AppPort is the port the first app listens on, behind the dev proxy.
+	AppPort int `toml:"app_port"`
+
+	// LiveReload makes the dev proxy inject a script that reloads the page
+	// after each restart and swaps changed stylesheets in place.
+	LiveReload bool `toml:"live_reload"`
+
+	// Keyboard enables the single-key commands when stdin is a terminal.
+	// The apps then get no stdin.
+	Keyboard bool `toml:"keyboard"`
+
+	// TestCmd is run by the t key. It defaults to the test subcommand of the
+	// running grove binary.
+	TestCmd string `toml:"test_cmd"`
+
+	// MigrateCmd is run by the m key. It defaults to the migrate subcommand
+	// of the running grove binary.
+	MigrateCmd string `toml:"migrate_cmd"`
+
+	// LogLevel hides structured app output below it: debug, info, warn or
+	// error. The l key cycles it.
+	LogLevel string `toml:"log_level"`
+
+	// LogFields, when set, are the only extra keys shown on structured
+	// lines, in this order.
+	LogFields []string `toml:"log_fields"`
+
+	// LogHide are extra keys never shown on structured lines.
+	LogHide []string `toml:"log_hide"`
+
+	// LogFormats are custom log line formats, tried in order before the
+	// built-in ones.
+	LogFormats []LogFormat `toml:"log_format"`
+
+	// PreBuild are commands run in order before every rebuild, e.g.
+	// "go generate ./...". A failing command cancels the rebuild.
+	PreBuild []string `toml:"pre_build"`
+
+	// PostBuild are commands run in order after a successful build and
+	// before the apps restart. A failing command cancels the restart.
+	PostBuild []string `toml:"post_build"`
+
+	// EnvFile lists dotenv files loaded into the apps' environment on every
+	// restart, later files overriding earlier ones. Saving one restarts the
+	// apps.
+	EnvFile []string `toml:"env_file"`
+
+	// Env holds the [dev.env] variables, which override EnvFile.
+	Env map[string]string `toml:"env"`
+
+	// Profiles holds the [dev.profiles.<name>] sets selectable with
+	// grove dev --profile.
+	Profiles map[string]Profile `toml:"profiles"`
+
+	// Profile is the name of the profile applied by WithProfile, if any.
+	Profile string `toml:"-"`
+
+	// Watch holds the [[dev.watch]] rules that restart, signal or run a
+	// command instead of rebuilding for the files they match.
+	Watch []WatchRule `toml:"watch"`
+
+	// Apps lists the binaries supervised by grove dev. It holds the
+	// [[app]] tables of grove.toml, or a single app built by BuildCmd when
+	// none are declared.
+	Apps []App `toml:"-"`
 }
 
+// App is one binary of the project, declared in grove.toml as:
+//
+//	[[app]]
+//	name = "worker"
+//	main = "./cmd/worker"
+//
+// Bin and BuildCmd default to .grove/tmp/<name> and "go build -o <bin> <main>".
+type App struct {
+	// Name identifies the app in prefixed output and in grove build --app.
+	Name string `toml:"name"`
+
+	// Main is the package path of the app's main package.
+	Main string `toml:"main"`
+
+	// Bin is the path grove dev compiles the app to and runs.
+	Bin string `toml:"bin"`
+
+	// BuildCmd is the shell command grove dev uses to compile the app.
+	BuildCmd string `toml:"build_cmd"`
+
+	// HealthURL is polled after each restart until it answers 2xx.
+	HealthURL string `toml:"health_url"`
+}
+
+// defaultApp is the app used when grove.toml declares no [[app]] tables.
+const defaultApp = "app"
+
+// defaultMain is the main package of the default app.
+const defaultMain = "./cmd/api/"
+
 // DefaultConfig returns a Config populated with sensible out-of-the-box
 // values so that grove dev works with zero configuration.
 func DefaultConfig() Config {
@@ -52,28 +167,84 @@ func DefaultConfig() Config {
 			".git",
 			"tests",
 		},
-		Extensions: []string{".go"},
-		DebounceMs: 50,
+		Extensions:    []string{".go"},
+		DebounceMs:    50,
+		HealthTimeout: 30 * time.Second,
+		ReadyWindow:   500 * time.Millisecond,
+		LiveReload:    true,
+		Keyboard:      true,
+		TestCmd:       groveCmd("test"),
+		MigrateCmd:    groveCmd("migrate"),
+		Apps: []App{{
+			Name:     defaultApp,
+			Main:     defaultMain,
+			Bin:      ".grove/tmp/app",
+			BuildCmd: "go build -o .grove/tmp/app ./cmd/api/",
+		}},
+	}
+}
+
+// groveCmd returns the command line running the grove subcommand sub with the
+// running binary, so the t and m keys work when grove is not on PATH. It
+// falls back to "grove" when the path cannot be split on spaces.
+func groveCmd(sub string) string {
+	exe, err := os.Executable()
+	if err != nil || strings.ContainsAny(exe, " \t") {
+		exe = "grove"
 	}
+	return exe + " " + sub
 }
 
 // groveFile mirrors the top-level structure of grove.toml so that the TOML
-// decoder can navigate directly to the [dev] table.
+// decoder can navigate directly to the [dev] table and the [[app]] array.
 type groveFile struct {
-	Dev devSection `toml:"dev"`
+	Dev  devSection `toml:"dev"`
+	Apps []App      `toml:"app"`
 }
 
 // devSection mirrors Config but with pointer fields so we can distinguish
 // "field was set in grove.toml" from "field was left at the zero value".
 type devSection struct {
-	Root       string   `toml:"root"`
-	TmpDir     string   `toml:"tmp_dir"`
-	Bin        string   `toml:"bin"`
-	BuildCmd   string   `toml:"build_cmd"`
-	WatchDirs  []string `toml:"watch_dirs"`
-	Exclude    []string `toml:"exclude"`
-	Extensions []string `toml:"extensions"`
-	DebounceMs int      `toml:"debounce_ms"`
+	Root          string             `toml:"root"`
+	TmpDir        string             `toml:"tmp_dir"`
+	Bin           string             `toml:"bin"`
+	BuildCmd      string             `toml:"build_cmd"`
+	WatchDirs     []string           `toml:"watch_dirs"`
+	Exclude       []string           `toml:"exclude"`
+	Extensions    []string           `toml:"extensions"`
+	DebounceMs    int                `toml:"debounce_ms"`
+	HealthURL     string             `toml:"health_url"`
+	HealthTimeout time.Duration      `toml:"health_timeout"`
+	ReadyWindow   time.Duration      `toml:"ready_window"`
+	ProxyPort     int                `toml:"proxy_port"`
+	AppPort       int                `toml:"app_port"`
+	LiveReload    *bool              `toml:"live_reload"`
+	Keyboard      *bool              `toml:"keyboard"`
+	TestCmd       string             `toml:"test_cmd"`
+	MigrateCmd    string             `toml:"migrate_cmd"`
+	LogLevel      string             `toml:"log_level"`
+	LogFields     []string           `toml:"log_fields"`
+	LogHide       []string           `toml:"log_hide"`
+	LogFormats    []LogFormat        `toml:"log_format"`
+	PreBuild      []string           `toml:"pre_build"`
+	PostBuild     []string           `toml:"post_build"`
+	EnvFile       []string           `toml:"env_file"`
+	Env           map[string]string  `toml:"env"`
+	Profiles      map[string]Profile `toml:"profiles"`
+	Watch         []WatchRule        `toml:"watch"`
+}
+
+// configFile is the project configuration, read from the working directory.
+const configFile = "grove.toml"
+
+// isConfigFile reports whether p names configFile.
+func isConfigFile(p string) bool {
+	abs, err := filepath.Abs(p)
+	if err != nil {
+		return false
+	}
+	want, err := filepath.Abs(configFile)
+	return err == nil && abs == want
 }
 
 // LoadConfig reads the [dev] section from grove.toml in the current working
@@ -84,7 +255,7 @@ type devSection struct {
 func LoadConfig() (Config, error) {
 	cfg := DefaultConfig()
 
-	raw, err := os.ReadFile("grove.toml")
+	raw, err := os.ReadFile(configFile)
 	if err != nil {
 		if os.IsNotExist(err) {
 			// No grove.toml — use defaults silently.
@@ -127,5 +298,117 @@ func LoadConfig() (Config, error) {
 		cfg.DebounceMs = dev.DebounceMs
 	}
 
+	if dev.HealthURL != "" {
+		cfg.HealthURL = dev.HealthURL
+	}
+	if dev.HealthTimeout > 0 {
+		cfg.HealthTimeout = dev.HealthTimeout
+	}
+	if dev.ReadyWindow > 0 {
+		cfg.ReadyWindow = dev.ReadyWindow
+	}
+	cfg.ProxyPort = dev.ProxyPort
+	cfg.AppPort = dev.AppPort
+	if cfg.ProxyPort > 0 && cfg.AppPort <= 0 {
+		return cfg, fmt.Errorf("grove.toml: [dev] proxy_port needs app_port, the port the app listens on")
+	}
+	if cfg.ProxyPort > 0 && cfg.ProxyPort == cfg.AppPort {
+		return cfg, fmt.Errorf("grove.toml: [dev] proxy_port and app_port must differ")
+	}
+	if dev.LiveReload != nil {
+		cfg.LiveReload = *dev.LiveReload
+	}
+
+	if dev.Keyboard != nil {
+		cfg.Keyboard = *dev.Keyboard
+	}
+	if dev.TestCmd != "" {
+		cfg.TestCmd = dev.TestCmd
+	}
+	if dev.MigrateCmd != "" {
+		cfg.MigrateCmd = dev.MigrateCmd
+	}
+
+	if err := ParseLogLevel(dev.LogLevel); err != nil {
+		return cfg, fmt.Errorf("grove.toml: [dev] log_level: %w", err)
+	}
+	cfg.LogLevel = dev.LogLevel
+	cfg.LogFields = dev.LogFields
+	cfg.LogHide = dev.LogHide
+	if _, err := compileLogFormats(dev.LogFormats); err != nil {
+		return cfg, fmt.Errorf("grove.toml: %w", err)
+	}
+	cfg.LogFormats = dev.LogFormats
+
+	if len(dev.PreBuild) > 0 {
+		cfg.PreBuild = dev.PreBuild
+	}
+	if len(dev.PostBuild) > 0 {
+		cfg.PostBuild = dev.PostBuild
+	}
+
+	cfg.EnvFile = dev.EnvFile
+	cfg.Env = dev.Env
+	cfg.Profiles = dev.Profiles
+
+	rules, err := resolveWatchRules(dev.Watch)
+	if err != nil {
+		return cfg, fmt.Errorf("grove.toml: %w", err)
+	}
+	cfg.Watch = rules
+	for _, rule := range rules {
+		if rule.Action == ActionReload && (cfg.ProxyPort == 0 || !cfg.LiveReload) {
+			return cfg, fmt.Errorf(
+				"grove.toml: [[dev.watch]] %q: the reload action needs proxy_port with live_reload",
+				rule.Pattern,
+			)
+		}
+	}
+
+	apps, err := resolveApps(cfg, file.Apps)
+	if err != nil {
+		return cfg, fmt.Errorf("grove.toml: %w", err)
+	}
+	cfg.Apps = apps
+
 	return cfg, nil
 }
+
+// resolveApps validates the declared [[app]] tables and fills in their
+// defaults. Without any, the single default app follows [dev].bin and
+// [dev].build_cmd.
+func resolveApps(cfg Config, declared []App) ([]App, error) {
+	if len(declared) == 0 {
+		return []App{{
+			Name:      defaultApp,
+			Main:      defaultMain,
+			Bin:       cfg.Bin,
+			BuildCmd:  cfg.BuildCmd,
+			HealthURL: cfg.HealthURL,
+		}}, nil
+	}
+
+	seen := make(map[string]bool, len(declared))
+	apps := make([]App, 0, len(declared))
+	for i, app := range declared {
+		if app.Name == "" {
+			return nil, fmt.Errorf("[[app]] #%d has no name", i+1)
+		}
+		if seen[app.Name] {
+			return nil, fmt.Errorf("[[app]] %q is declared twice", app.Name)
+		}
+		seen[app.Name] = true
+
+		if app.Main == "" {
+			return nil, fmt.Errorf("[[app]] %q has no main package", app.Name)
+		}
+		if app.Bin == "" {
+			app.Bin = filepath.ToSlash(filepath.Join(cfg.TmpDir, app.Name))
+		}
+		if app.BuildCmd == "" {
+			app.BuildCmd = "go build -o " + app.Bin + " " + app.Main
+		}
+		apps = append(apps, app)
+	}
+	return apps, nil
+}
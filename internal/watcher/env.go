@@ -0,0 +1,217 @@
+package watcher
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ── Environment ───────────────────────────────────────────────────────────────
+
+// Profile is a named set of environment settings declared as
+// [dev.profiles.<name>] and selected with grove dev --profile <name>.
+//
+//	[dev.profiles.staging]
+//	env_file = [".env.staging"]
+//
+//	[dev.profiles.staging.env]
+//	API_URL = "https://staging.example.com"
+type Profile struct {
+	// EnvFile is loaded after the [dev] env files.
+	EnvFile []string `toml:"env_file"`
+
+	// Env overrides the [dev.env] values.
+	Env map[string]string `toml:"env"`
+}
+
+// WithProfile returns a copy of c with the named profile layered on top of
+// its env files and inline variables.
+func (c Config) WithProfile(name string) (Config, error) {
+	p, ok := c.Profiles[name]
+	if !ok {
+		names := make([]string, 0, len(c.Profiles))
+		for n := range c.Profiles {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		if len(names) == 0 {
+			return c, fmt.Errorf("unknown profile %q (grove.toml declares no [dev.profiles])", name)
+		}
+		return c, fmt.Errorf(
+			"unknown profile %q (declared in grove.toml: %s)",
+			name, strings.Join(names, ", "),
+		)
+	}
+
+	c.Profile = name
+	c.EnvFile = append(append([]string{}, c.EnvFile...), p.EnvFile...)
+
+	env := make(map[string]string, len(c.Env)+len(p.Env))
+	for k, v := range c.Env {
+		env[k] = v
+	}
+	for k, v := range p.Env {
+		env[k] = v
+	}
+	c.Env = env
+
+	return c, nil
+}
+
+// environ returns the environment the apps are started with: grove's own
+// environment, overridden by each env file in order, then by the inline
+// variables. Env files are re-read on every call so edits apply on the next
+// restart; a missing file is skipped. It returns nil, meaning "inherit",
+// when nothing is configured.
+func (w *Watcher) environ() []string {
+	if len(w.cfg.EnvFile) == 0 && len(w.cfg.Env) == 0 {
+		return nil
+	}
+
+	env := os.Environ()
+	vars := map[string]string{}
+
+	for _, name := range w.cfg.EnvFile {
+		path := filepath.Join(w.cfg.Root, name)
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			if !os.IsNotExist(err) {
+				logDev(ansiYellow + "⚠  Cannot read " + name + ": " + err.Error() + ansiReset)
+			}
+			continue
+		}
+
+		parsed, err := parseEnvFile(raw, vars)
+		if err != nil {
+			logDev(ansiYellow + "⚠  " + name + ": " + err.Error() + ansiReset)
+		}
+		for _, kv := range parsed {
+			vars[kv[0]] = kv[1]
+			env = append(env, kv[0]+"="+kv[1])
+		}
+	}
+
+	// Inline variables may reference the env files, e.g. "${DB_HOST}:5432".
+	keys := make([]string, 0, len(w.cfg.Env))
+	for k := range w.cfg.Env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		env = append(env, k+"="+expandEnv(w.cfg.Env[k], vars))
+	}
+
+	// exec.Cmd uses the last value of a duplicated key, so the overrides
+	// appended above win over the inherited environment.
+	return env
+}
+
+// isEnvFile reports whether p is one of the configured env files.
+func (w *Watcher) isEnvFile(p string) bool {
+	rel := relPath(w.cfg.Root, p)
+	for _, name := range w.cfg.EnvFile {
+		if filepath.ToSlash(filepath.Clean(name)) == rel {
+			return true
+		}
+	}
+	return false
+}
+
+// parseEnvFile parses a dotenv file into key/value pairs, in file order.
+// It accepts "KEY=value", an optional "export " prefix, # comments, single
+// quoted literals and double quoted values with \n, \t, \" and \\ escapes.
+// ${VAR} and $VAR are expanded in unquoted and double quoted values, from
+// vars first and then the process environment.
+//
+// On a malformed line the pairs parsed so far are returned with the error.
+func parseEnvFile(raw []byte, vars map[string]string) ([][2]string, error) {
+	seen := make(map[string]string, len(vars))
+	for k, v := range vars {
+		seen[k] = v
+	}
+
+	var out [][2]string
+	scanner := bufio.NewScanner(bytes.NewReader(raw))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if n == 1 {
+			line = strings.TrimPrefix(line, "\ufeff")
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" || strings.ContainsAny(key, " \t") {
+			return out, fmt.Errorf("line %d: expected KEY=value", n)
+		}
+		value = strings.TrimSpace(value)
+
+		switch {
+		case strings.HasPrefix(value, "'"):
+			end := strings.IndexByte(value[1:], '\'')
+			if end < 0 {
+				return out, fmt.Errorf("line %d: unterminated ' quote", n)
+			}
+			value = value[1 : end+1]
+
+		case strings.HasPrefix(value, `"`):
+			unquoted, ok := unquoteEnv(value[1:])
+			if !ok {
+				return out, fmt.Errorf("line %d: unterminated \" quote", n)
+			}
+			value = expandEnv(unquoted, seen)
+
+		default:
+			if i := strings.Index(value, " #"); i >= 0 {
+				value = strings.TrimSpace(value[:i])
+			}
+			value = expandEnv(value, seen)
+		}
+
+		seen[key] = value
+		out = append(out, [2]string{key, value})
+	}
+	return out, scanner.Err()
+}
+
+// unquoteEnv returns s up to its closing double quote with escapes resolved.
+func unquoteEnv(s string) (string, bool) {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '"':
+			return b.String(), true
+		case c == '\\' && i+1 < len(s):
+			i++
+			switch s[i] {
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			default:
+				b.WriteByte(s[i])
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", false
+}
+
+// expandEnv replaces ${VAR} and $VAR in s from vars, falling back to the
+// process environment.
+func expandEnv(s string, vars map[string]string) string {
+	return os.Expand(s, func(name string) string {
+		if v, ok := vars[name]; ok {
+			return v
+		}
+		return os.Getenv(name)
+	})
+}
@@ -0,0 +1,153 @@
+package watcher
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// ── Keyboard controls ─────────────────────────────────────────────────────────
+
+// keyBindings lists the single-key commands, in the order ? shows them.
+var keyBindings = []struct {
+	key  byte
+	help string
+}{
+	{'r', "rebuild and restart"},
+	{'c', "clear the screen"},
+	{'l', "cycle the minimum log level"},
+	{'t', "run the tests"},
+	{'m', "apply pending migrations"},
+	{'q', "quit"},
+	{'?', "show this help"},
+}
+
+// startKeyboard puts the terminal in cbreak mode and returns the keys read
+// from stdin, with a function restoring the terminal. The channel is nil when
+// keyboard controls are disabled or stdin is not a terminal; the apps then
+// keep reading stdin themselves.
+func (w *Watcher) startKeyboard() (<-chan byte, func()) {
+	fd := int(os.Stdin.Fd())
+	if !w.cfg.Keyboard || !isTerminal(fd) {
+		return nil, func() {}
+	}
+	restore, err := enableCBreak(fd)
+	if err != nil {
+		logDev(ansiYellow + "⚠  Keyboard controls disabled: " + err.Error() + ansiReset)
+		return nil, func() {}
+	}
+
+	for _, a := range w.apps {
+		a.proc.stdin = nil
+	}
+
+	keys := make(chan byte)
+	go func() {
+		buf := make([]byte, 1)
+		for {
+			if n, err := os.Stdin.Read(buf); err != nil {
+				return
+			} else if n == 1 {
+				keys <- buf[0]
+			}
+		}
+	}()
+	return keys, restore
+}
+
+// handleKey performs the command bound to key and reports whether grove dev
+// should quit. Commands that run something are queued on the rebuild worker
+// so they never overlap a build.
+func (w *Watcher) handleKey(key byte) (quit bool) {
+	switch key {
+	case 'r':
+		w.forceRebuild()
+
+	case 'c':
+		fmt.Print("\033[H\033[2J\033[3J")
+		w.printHeader(true)
+
+	case 'l':
+		level := w.view.cycleLevel()
+		fmt.Println()
+		logDev(
+			badge(ansiBgBlue, "LOG LEVEL") + "  " + ansiGray + "showing " +
+				ansiReset + ansiBold + level + ansiReset + ansiGray + " and above" + ansiReset,
+		)
+		fmt.Println()
+
+	case 't':
+		w.queueTask(func() { w.runTool(w.cfg.TestCmd, "TESTS PASSED", "TESTS FAILED") })
+
+	case 'm':
+		w.queueTask(func() { w.runTool(w.cfg.MigrateCmd, "MIGRATED", "MIGRATION FAILED") })
+
+	case 'q':
+		return true
+
+	case '?', 'h':
+		fmt.Println()
+		logDev(badge(ansiBgGrove, "KEYS"))
+		fmt.Println()
+		for _, b := range keyBindings {
+			logDev("  " + ansiBold + string(b.key) + ansiReset + "  " + ansiGray + b.help + ansiReset)
+		}
+		fmt.Println()
+	}
+	return false
+}
+
+// forceRebuild rebuilds and restarts every app, cancelling the builds in
+// flight.
+func (w *Watcher) forceRebuild() {
+	w.mu.Lock()
+	w.forceBuild = true
+	if w.cancelBuild != nil {
+		w.cancelBuild()
+	}
+	w.mu.Unlock()
+
+	select {
+	case w.rebuildCh <- struct{}{}:
+	default:
+	}
+}
+
+// queueTask hands task to the rebuild worker. A key pressed while the
+// previous task is still queued is ignored.
+func (w *Watcher) queueTask(task func()) {
+	select {
+	case w.tasks <- task:
+	default:
+		logDev(ansiGray + "already queued — wait for the current run to finish" + ansiReset)
+	}
+}
+
+// runTool runs a test or migration command in cfg.Root with the apps'
+// environment, its output left untouched, and reports the outcome with the
+// ok or failed badge.
+func (w *Watcher) runTool(line, ok, failed string) {
+	fmt.Println()
+	logDev(badge(ansiBgBlue, "RUNNING") + "  " + ansiGray + line + ansiReset)
+	fmt.Println()
+
+	parts := strings.Fields(line)
+	if len(parts) == 0 {
+		return
+	}
+	cmd := exec.Command(parts[0], parts[1:]...)
+	cmd.Dir = w.cfg.Root
+	cmd.Env = w.environ()
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	err := cmd.Run()
+	fmt.Println()
+	if err != nil {
+		logDev(badge(ansiBgRed, failed) + "  " + ansiRed + err.Error() + ansiReset)
+	} else {
+		logDev(badge(ansiBgGreen, ok))
+	}
+	fmt.Println()
+}
@@ -0,0 +1,177 @@
+package watcher
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ── Live reload ───────────────────────────────────────────────────────────────
+
+const (
+	// liveReloadScriptPath serves the script injected into HTML pages.
+	liveReloadScriptPath = "/__grove/livereload.js"
+	// liveReloadEventsPath is the server-sent events stream the script
+	// listens to.
+	liveReloadEventsPath = "/__grove/events"
+)
+
+// liveReload injects a script into the HTML served through the dev proxy and
+// tells the connected pages, over server-sent events, to reload or to swap
+// their stylesheets.
+type liveReload struct {
+	mu      sync.Mutex
+	clients map[chan string]struct{}
+}
+
+func newLiveReload() *liveReload {
+	return &liveReload{clients: map[chan string]struct{}{}}
+}
+
+// Reload makes every connected page reload.
+func (l *liveReload) Reload() {
+	l.broadcast("reload", []string{})
+}
+
+// ReloadCSS makes every connected page re-fetch the stylesheets whose file
+// name is in names, without reloading. A page linking none of them reloads.
+func (l *liveReload) ReloadCSS(names []string) {
+	l.broadcast("css", names)
+}
+
+func (l *liveReload) broadcast(event string, data []string) {
+	raw, _ := json.Marshal(data)
+	msg := "event: " + event + "\ndata: " + string(raw) + "\n\n"
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for ch := range l.clients {
+		// A page that has not read the previous event yet does not need
+		// another one.
+		select {
+		case ch <- msg:
+		default:
+		}
+	}
+}
+
+// ServeHTTP serves the script and the event stream.
+func (l *liveReload) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case liveReloadScriptPath:
+		w.Header().Set("Content-Type", "text/javascript; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+		_, _ = io.WriteString(w, liveReloadScript)
+	case liveReloadEventsPath:
+		l.serveEvents(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (l *liveReload) serveEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	ch := make(chan string, 1)
+	l.mu.Lock()
+	l.clients[ch] = struct{}{}
+	l.mu.Unlock()
+	defer func() {
+		l.mu.Lock()
+		delete(l.clients, ch)
+		l.mu.Unlock()
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-store")
+	fmt.Fprint(w, ": grove dev\n\n")
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case msg := <-ch:
+			fmt.Fprint(w, msg)
+			flusher.Flush()
+		}
+	}
+}
+
+// inject adds the live reload script to HTML responses. It is installed as
+// the reverse proxy's ModifyResponse hook.
+func (l *liveReload) inject(resp *http.Response) error {
+	if !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/html") ||
+		resp.Header.Get("Content-Encoding") != "" ||
+		resp.Request.Method == http.MethodHead {
+		return nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close() //nolint:errcheck
+	if err != nil {
+		return err
+	}
+	body = injectScript(body)
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	resp.ContentLength = int64(len(body))
+	resp.Header.Set("Content-Length", strconv.Itoa(len(body)))
+	return nil
+}
+
+// liveReloadTag loads the live reload script.
+const liveReloadTag = `<script src="` + liveReloadScriptPath + `"></script>`
+
+// injectScript inserts liveReloadTag before the last </body>, or appends it
+// to a non-empty page without one.
+func injectScript(body []byte) []byte {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return body
+	}
+	i := bytes.LastIndex(bytes.ToLower(body), []byte("</body>"))
+	if i < 0 {
+		return append(body, liveReloadTag...)
+	}
+
+	out := make([]byte, 0, len(body)+len(liveReloadTag))
+	out = append(out, body[:i]...)
+	out = append(out, liveReloadTag...)
+	return append(out, body[i:]...)
+}
+
+// liveReloadScript listens to the event stream. A "css" event lists the file
+// names of changed stylesheets: each matching <link> is replaced by a fresh
+// copy once that copy has loaded, so the page never flashes unstyled.
+const liveReloadScript = `(function () {
+  if (!window.EventSource || window.__groveLiveReload) return;
+  window.__groveLiveReload = true;
+
+  var events = new EventSource("` + liveReloadEventsPath + `");
+  events.addEventListener("reload", function () { location.reload(); });
+  events.addEventListener("css", function (e) {
+    var names = JSON.parse(e.data);
+    var swapped = 0;
+    document.querySelectorAll('link[rel="stylesheet"]').forEach(function (link) {
+      var url = new URL(link.href, location.href);
+      if (names.indexOf(url.pathname.split("/").pop()) < 0) return;
+      url.searchParams.set("grove", Date.now());
+      var next = link.cloneNode();
+      next.href = url.href;
+      next.onload = function () { link.remove(); };
+      link.after(next);
+      swapped++;
+    });
+    if (!swapped) location.reload();
+  });
+})();
+`
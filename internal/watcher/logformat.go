@@ -0,0 +1,459 @@
+package watcher
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ── Log formats ───────────────────────────────────────────────────────────────
+
+// logEntry is a structured log line, as recognised by a logParser.
+type logEntry struct {
+	// Time, Level and Msg are as logged; Time and Level may be empty.
+	Time  string
+	Level string
+	Msg   string
+	// Fields are the other keys of the line, in display order.
+	Fields []logField
+}
+
+// logField is one extra key of a logEntry.
+type logField struct {
+	Key   string
+	Value string
+}
+
+// logParser recognises the lines of one log format.
+type logParser interface {
+	// parse returns the entry logged by line, or false when line is not in
+	// the parser's format.
+	parse(line string) (logEntry, bool)
+}
+
+// builtinLogParsers are tried in order, after the custom formats of
+// grove.toml, until one recognises the line. The strictest formats come
+// first so a line is never claimed by a looser one.
+var builtinLogParsers = []logParser{
+	jsonLogParser{},
+	logfmtParser{},
+	logrusTextParser{},
+	zapConsoleParser{},
+	clfParser{},
+	stdLogParser{},
+}
+
+// LogFormat is a custom log line format, a [[dev.log_format]] table.
+type LogFormat struct {
+	// Name identifies the format in error messages and the dev header.
+	Name string `toml:"name"`
+
+	// Pattern is a regular expression matching a whole line. Its named
+	// groups time, level and msg are rendered as such; msg is required.
+	// Other named groups become fields.
+	Pattern string `toml:"pattern"`
+}
+
+// compileLogFormats returns a parser for each format, in order.
+func compileLogFormats(formats []LogFormat) ([]logParser, error) {
+	parsers := make([]logParser, 0, len(formats))
+	for i, f := range formats {
+		name := f.Name
+		if name == "" {
+			name = "#" + strconv.Itoa(i+1)
+		}
+		re, err := regexp.Compile(f.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("[[dev.log_format]] %s: %w", name, err)
+		}
+		if !slices.Contains(re.SubexpNames(), "msg") {
+			return nil, fmt.Errorf(
+				"[[dev.log_format]] %s: pattern needs a (?P<msg>...) group",
+				name,
+			)
+		}
+		parsers = append(parsers, regexLogParser{re: re})
+	}
+	return parsers, nil
+}
+
+// regexLogParser parses a LogFormat.
+type regexLogParser struct {
+	re *regexp.Regexp
+}
+
+func (p regexLogParser) parse(line string) (logEntry, bool) {
+	m := p.re.FindStringSubmatch(line)
+	if m == nil {
+		return logEntry{}, false
+	}
+
+	var e logEntry
+	for i, name := range p.re.SubexpNames() {
+		switch name {
+		case "":
+		case "time":
+			e.Time = m[i]
+		case "level":
+			e.Level = m[i]
+		case "msg":
+			e.Msg = m[i]
+		default:
+			if m[i] != "" {
+				e.Fields = append(e.Fields, logField{name, m[i]})
+			}
+		}
+	}
+	return e, e.Msg != ""
+}
+
+// ── JSON ──────────────────────────────────────────────────────────────────────
+
+// jsonLogParser parses the JSON lines of slog's JSONHandler, zap and zerolog.
+// A msg or message key is required; the other keys are sorted.
+type jsonLogParser struct{}
+
+// Keys holding the standard fields, in order of preference.
+var (
+	timeKeys  = []string{"time", "ts", "timestamp", "Time"}
+	levelKeys = []string{"level", "lvl", "severity"}
+	msgKeys   = []string{"msg", "message", "Message"}
+)
+
+func (jsonLogParser) parse(line string) (logEntry, bool) {
+	if !strings.HasPrefix(line, "{") {
+		return logEntry{}, false
+	}
+	var m map[string]any
+	if err := json.Unmarshal([]byte(line), &m); err != nil {
+		return logEntry{}, false
+	}
+
+	e := logEntry{
+		Time:  jsonString(m, timeKeys),
+		Level: jsonString(m, levelKeys),
+		Msg:   jsonString(m, msgKeys),
+	}
+	if e.Msg == "" {
+		return logEntry{}, false
+	}
+
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		if !isStandardKey(k) {
+			keys = append(keys, k)
+		}
+	}
+	// Map order is random; sort so consecutive lines line up.
+	slices.Sort(keys)
+	for _, k := range keys {
+		e.Fields = append(e.Fields, logField{k, jsonValue(m[k])})
+	}
+	return e, true
+}
+
+// jsonString returns the first non-empty string value of keys in m.
+func jsonString(m map[string]any, keys []string) string {
+	for _, k := range keys {
+		if s, ok := m[k].(string); ok && s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+// jsonValue formats a decoded JSON value for display: strings as is, the
+// rest as JSON.
+func jsonValue(v any) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	raw, _ := json.Marshal(v)
+	return string(raw)
+}
+
+// isStandardKey reports whether k holds the time, level or message.
+func isStandardKey(k string) bool {
+	return slices.Contains(timeKeys, k) || slices.Contains(levelKeys, k) ||
+		slices.Contains(msgKeys, k)
+}
+
+// ── logfmt ────────────────────────────────────────────────────────────────────
+
+// logfmtParser parses key=value lines, as written by slog's TextHandler and
+// by logrus when its output is not a terminal:
+//
+//	time=2006-01-02T15:04:05.000Z level=INFO msg="hello world" key=val
+//	time="2006-01-02T15:04:05Z" level=info msg="hello world" key=val
+//
+// A level and a msg or message key are required.
+type logfmtParser struct{}
+
+func (logfmtParser) parse(line string) (logEntry, bool) {
+	fields, _ := parseLogfmt(line)
+
+	var e logEntry
+	for _, f := range fields {
+		switch {
+		case slices.Contains(timeKeys, f.Key):
+			e.Time = cmp.Or(e.Time, f.Value)
+		case slices.Contains(levelKeys, f.Key):
+			e.Level = cmp.Or(e.Level, f.Value)
+		case slices.Contains(msgKeys, f.Key):
+			e.Msg = cmp.Or(e.Msg, f.Value)
+		default:
+			e.Fields = append(e.Fields, f)
+		}
+	}
+	if e.Level == "" || e.Msg == "" {
+		return logEntry{}, false
+	}
+	return e, true
+}
+
+// parseLogfmt parses key=value pairs, in line order, until a token is not one.
+// Values may be bare or double-quoted with \" escapes. Repeated keys keep their
+// first value. It also reports whether the whole line was consumed.
+func parseLogfmt(line string) ([]logField, bool) {
+	var fields []logField
+	rest := line
+	for {
+		rest = strings.TrimLeft(rest, " \t")
+		if rest == "" {
+			return fields, len(fields) > 0
+		}
+
+		// Find the '=' that separates key from value.
+		eq := strings.IndexByte(rest, '=')
+		if eq <= 0 {
+			return fields, false
+		}
+		key := rest[:eq]
+		// Keys must be plain identifiers (no spaces).
+		if strings.ContainsAny(key, " \t\"") {
+			return fields, false
+		}
+		rest = rest[eq+1:]
+
+		var val string
+		if rest != "" && rest[0] == '"' {
+			// Quoted value — scan to the closing unescaped '"'.
+			i := 1
+			var buf strings.Builder
+			for i < len(rest) {
+				if rest[i] == '\\' && i+1 < len(rest) {
+					buf.WriteByte(rest[i+1])
+					i += 2
+					continue
+				}
+				if rest[i] == '"' {
+					i++
+					break
+				}
+				buf.WriteByte(rest[i])
+				i++
+			}
+			val = buf.String()
+			rest = rest[i:]
+		} else {
+			// Bare value — ends at the next space.
+			end := strings.IndexAny(rest, " \t")
+			if end < 0 {
+				end = len(rest)
+			}
+			val, rest = rest[:end], rest[end:]
+		}
+
+		if !slices.ContainsFunc(fields, func(f logField) bool { return f.Key == key }) {
+			fields = append(fields, logField{key, val})
+		}
+	}
+}
+
+// ── logrus ────────────────────────────────────────────────────────────────────
+
+// logrusTextParser parses the coloured text logrus writes to a terminal or
+// with ForceColors, ignoring the colours. The message is padded before the
+// fields:
+//
+//	INFO[0000] Starting server                               port=8080
+//	WARN[2006-01-02T15:04:05Z] Slow query                    took=2s
+type logrusTextParser struct{}
+
+var (
+	logrusLine = regexp.MustCompile(`^(TRAC|DEBU|INFO|WARN|ERRO|FATA|PANI)\[([^\]]*)\] ?(.*)$`)
+	ansiEscape = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+)
+
+func (logrusTextParser) parse(line string) (logEntry, bool) {
+	m := logrusLine.FindStringSubmatch(ansiEscape.ReplaceAllString(line, ""))
+	if m == nil {
+		return logEntry{}, false
+	}
+	e := logEntry{Time: m[2], Level: m[1], Msg: m[3]}
+
+	// The fields are the longest suffix that is entirely key=value pairs.
+	for i := 0; i < len(e.Msg); i++ {
+		if e.Msg[i] != ' ' {
+			continue
+		}
+		if fields, ok := parseLogfmt(e.Msg[i:]); ok {
+			e.Msg, e.Fields = strings.TrimSpace(e.Msg[:i]), fields
+			break
+		}
+	}
+	return e, true
+}
+
+// ── zap console ───────────────────────────────────────────────────────────────
+
+// zapConsoleParser parses the tab-separated lines of zap's console encoder:
+// time, level, then optionally the logger name and the caller, the message
+// and the fields as a JSON object:
+//
+//	2006-01-02T15:04:05.000-0700	INFO	server/main.go:42	Listening	{"port": 8080}
+type zapConsoleParser struct{}
+
+var zapCaller = regexp.MustCompile(`^\S+\.go:\d+$`)
+
+func (zapConsoleParser) parse(line string) (logEntry, bool) {
+	parts := strings.Split(line, "\t")
+	if len(parts) < 3 || levelRank(parts[1]) < 0 {
+		return logEntry{}, false
+	}
+	e := logEntry{Time: parts[0], Level: parts[1]}
+	rest := parts[2:]
+
+	var fields []logField
+	if last := rest[len(rest)-1]; len(rest) > 1 && strings.HasPrefix(last, "{") {
+		var m map[string]any
+		if json.Unmarshal([]byte(last), &m) == nil {
+			keys := make([]string, 0, len(m))
+			for k := range m {
+				keys = append(keys, k)
+			}
+			slices.Sort(keys)
+			for _, k := range keys {
+				fields = append(fields, logField{k, jsonValue(m[k])})
+			}
+			rest = rest[:len(rest)-1]
+		}
+	}
+
+	// The message is the last column left; a caller and a logger name may
+	// precede it.
+	e.Msg = rest[len(rest)-1]
+	for _, col := range rest[:len(rest)-1] {
+		if zapCaller.MatchString(col) {
+			e.Fields = append(e.Fields, logField{"caller", col})
+		} else {
+			e.Fields = append(e.Fields, logField{"logger", col})
+		}
+	}
+	e.Fields = append(e.Fields, fields...)
+	return e, e.Msg != ""
+}
+
+// ── Common Log Format ─────────────────────────────────────────────────────────
+
+// clfParser parses Apache access log lines in the Common or Combined Log
+// Format, as written by gorilla/handlers. The level follows the status: warn
+// for 4xx, error for 5xx.
+//
+//	127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /a.gif HTTP/1.0" 200 2326
+type clfParser struct{}
+
+var clfLine = regexp.MustCompile(
+	`^(\S+) \S+ (\S+) \[([^\]]+)\] "([^"]*)" (\d{3}) (\d+|-)(?: "([^"]*)" "([^"]*)")?$`,
+)
+
+func (clfParser) parse(line string) (logEntry, bool) {
+	m := clfLine.FindStringSubmatch(line)
+	if m == nil {
+		return logEntry{}, false
+	}
+
+	// Drop the protocol from the request line: "GET /a.gif".
+	msg := m[4]
+	if method, target, ok := strings.Cut(msg, " "); ok {
+		target, _, _ = strings.Cut(target, " ")
+		msg = method + " " + target
+	}
+
+	level := "info"
+	switch m[5][0] {
+	case '4':
+		level = "warn"
+	case '5':
+		level = "error"
+	}
+
+	e := logEntry{Time: m[3], Level: level, Msg: msg}
+	e.Fields = append(e.Fields,
+		logField{"status", m[5]},
+		logField{"bytes", m[6]},
+		logField{"remote", m[1]},
+	)
+	for _, f := range []logField{{"user", m[2]}, {"referer", m[7]}, {"user_agent", m[8]}} {
+		if f.Value != "" && f.Value != "-" {
+			e.Fields = append(e.Fields, f)
+		}
+	}
+	return e, true
+}
+
+// ── Standard library log ──────────────────────────────────────────────────────
+
+// stdLogParser parses the lines of the standard log package with its date
+// prefix, optionally with microseconds and the file name:
+//
+//	2006/01/02 15:04:05 listening on :8080
+//	2006/01/02 15:04:05.000000 main.go:12: [WARN] cache miss
+//
+// A level in front of the message, as "[WARN]" or "WARN:", is picked up.
+type stdLogParser struct{}
+
+var (
+	stdLogLine  = regexp.MustCompile(`^\d{4}/\d{2}/\d{2} (\d{2}:\d{2}:\d{2}(?:\.\d+)?) (?:(\S+\.go:\d+): )?(.*)$`)
+	stdLogLevel = regexp.MustCompile(`^(?:\[([A-Za-z]+)\]|([A-Za-z]+):)\s+`)
+)
+
+func (stdLogParser) parse(line string) (logEntry, bool) {
+	m := stdLogLine.FindStringSubmatch(line)
+	if m == nil {
+		return logEntry{}, false
+	}
+	e := logEntry{Time: m[1], Msg: m[3]}
+	if l := stdLogLevel.FindStringSubmatch(e.Msg); l != nil {
+		if level := l[1] + l[2]; levelRank(level) >= 0 {
+			e.Level, e.Msg = level, e.Msg[len(l[0]):]
+		}
+	}
+	if m[2] != "" {
+		e.Fields = append(e.Fields, logField{"caller", m[2]})
+	}
+	return e, e.Msg != ""
+}
+
+// ── Timestamps ────────────────────────────────────────────────────────────────
+
+// clockPattern finds HH:MM:SS, not preceded by a digit so the year of
+// "10/Oct/2000:13:55:36" is skipped.
+var clockPattern = regexp.MustCompile(`(?:^|\D)(\d{2}:\d{2}:\d{2})`)
+
+// clockTime returns the HH:MM:SS of a logged timestamp in any of the formats
+// above, including Unix seconds as zap writes them, or "" when it has none.
+func clockTime(ts string) string {
+	if m := clockPattern.FindStringSubmatch(ts); m != nil {
+		return m[1]
+	}
+	if secs, err := strconv.ParseFloat(ts, 64); err == nil && secs > 1e9 {
+		return time.Unix(int64(secs), 0).Format(time.TimeOnly)
+	}
+	return ""
+}
@@ -0,0 +1,355 @@
+package watcher
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ── Log capture ───────────────────────────────────────────────────────────────
+
+// LogDir holds the output captured from every grove dev run, in
+// <run>-<n>.jsonl files: <run> is the time the run started and <n> counts
+// the files it moved on to.
+var LogDir = filepath.Join(".grove", "logs")
+
+const (
+	// logKeep is the number of log files kept in LogDir; older ones are
+	// deleted when a new file is started.
+	logKeep = 20
+
+	// logMaxSize is the size at which a run moves on to a new log file.
+	logMaxSize = 10 << 20
+
+	// logFileTime formats the start time of the run naming a log file, so
+	// that sorting the names sorts the files chronologically.
+	logFileTime = "20060102-150405.000"
+)
+
+// logRun returns the run a log file belongs to: the start time prefixing its
+// name.
+func logRun(path string) string {
+	name := filepath.Base(path)
+	if len(name) < len(logFileTime) {
+		return name
+	}
+	return name[:len(logFileTime)]
+}
+
+// LogRecord is one line of app output as stored in a log file.
+type LogRecord struct {
+	// Time is when grove dev read the line.
+	Time time.Time `json:"time"`
+
+	// App is the name of the app that wrote the line.
+	App string `json:"app"`
+
+	// Line is the line exactly as the app wrote it.
+	Line string `json:"line"`
+}
+
+// logRecorder appends the lines written by the apps to the run's log file.
+// It is safe for concurrent use and a nil *logRecorder records nothing.
+type logRecorder struct {
+	mu   sync.Mutex
+	dir  string
+	run  string // start time of the run, prefixing its file names
+	seq  int    // number of files started by the run
+	f    *os.File
+	size int64
+}
+
+// newLogRecorder starts a new log file in dir, deleting the oldest ones
+// beyond logKeep.
+func newLogRecorder(dir string) (*logRecorder, error) {
+	r := &logRecorder{dir: dir, run: time.Now().Format(logFileTime)}
+	if err := r.rotate(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// rotate closes the current file and opens a new one. r.mu must be held.
+func (r *logRecorder) rotate() error {
+	if r.f != nil {
+		r.f.Close() //nolint:errcheck
+		r.f = nil
+	}
+	if err := os.MkdirAll(r.dir, 0o755); err != nil {
+		return err
+	}
+
+	files, _ := logFiles(r.dir)
+	for len(files) >= logKeep {
+		_ = os.Remove(files[0])
+		files = files[1:]
+	}
+
+	r.seq++
+	path := filepath.Join(r.dir, fmt.Sprintf("%s-%03d.jsonl", r.run, r.seq))
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	r.f, r.size = f, 0
+	return nil
+}
+
+// Record appends line, written by app, to the log file.
+func (r *logRecorder) Record(app, line string) {
+	if r == nil {
+		return
+	}
+	raw, err := json.Marshal(LogRecord{Time: time.Now(), App: app, Line: line})
+	if err != nil {
+		return
+	}
+	raw = append(raw, '\n')
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.f == nil {
+		return
+	}
+	if r.size+int64(len(raw)) > logMaxSize {
+		if err := r.rotate(); err != nil {
+			return
+		}
+	}
+	n, _ := r.f.Write(raw)
+	r.size += int64(n)
+}
+
+// Close closes the log file.
+func (r *logRecorder) Close() {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.f != nil {
+		r.f.Close() //nolint:errcheck
+		r.f = nil
+	}
+}
+
+// logFiles returns the log files in dir, oldest first.
+func logFiles(dir string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.jsonl"))
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(files)
+	return files, nil
+}
+
+// ── Log queries ───────────────────────────────────────────────────────────────
+
+// LogQuery selects the captured lines shown by grove logs.
+type LogQuery struct {
+	// Level hides structured lines below it: debug, info, warn or error.
+	// Lines without a recognised level are always shown.
+	Level string
+
+	// Grep keeps only the lines containing it, ignoring case.
+	Grep string
+
+	// Since keeps only the lines captured after it. When zero, only the
+	// latest run is shown.
+	Since time.Time
+
+	// App keeps only the lines of the named app.
+	App string
+
+	// Fields, when set, are the only extra keys shown.
+	Fields []string
+
+	// Hide are extra keys never shown.
+	Hide []string
+
+	// Formats are the custom log formats to parse lines with.
+	Formats []LogFormat
+}
+
+// logPrinter renders records like grove dev does, each app through its own
+// output writer so panic dumps are reassembled.
+type logPrinter struct {
+	w       io.Writer
+	query   LogQuery
+	view    *logView
+	writers map[string]*appOutputWriter
+	matched int // number of records printed
+}
+
+func newLogPrinter(w io.Writer, q LogQuery) *logPrinter {
+	view := &logView{}
+	view.apply(q.Level, q.Fields, q.Hide)
+	view.useFormats(q.Formats)
+	q.Grep = strings.ToLower(q.Grep)
+	return &logPrinter{w: w, query: q, view: view, writers: map[string]*appOutputWriter{}}
+}
+
+// print renders rec if it matches the query.
+func (p *logPrinter) print(rec LogRecord) {
+	q := p.query
+	if q.App != "" && rec.App != q.App {
+		return
+	}
+	if !q.Since.IsZero() && rec.Time.Before(q.Since) {
+		return
+	}
+	if q.Grep != "" && !strings.Contains(strings.ToLower(rec.Line), q.Grep) {
+		return
+	}
+	if entry, ok := p.view.parse(strings.TrimSpace(rec.Line)); ok && !p.view.shows(entry.Level) {
+		return
+	}
+
+	aw, ok := p.writers[rec.App]
+	if !ok {
+		// Label the apps of multi-app projects, in order of appearance.
+		label := ""
+		if rec.App != defaultApp {
+			colour := appColours[len(p.writers)%len(appColours)]
+			label = colour + ansiBold + rec.App + ansiReset + " " + ansiGray + "│" + ansiReset
+		}
+		aw = newAppOutputWriter(&prefixWriter{w: p.w, prefix: label, atStart: true}, p.view)
+		p.writers[rec.App] = aw
+	}
+	aw.writeLine(rec.Line)
+	p.matched++
+}
+
+// flush prints the panic dumps still being reassembled.
+func (p *logPrinter) flush() {
+	for _, aw := range p.writers {
+		aw.Flush()
+	}
+}
+
+// PrintLogs renders the lines captured in dir that match q to w, with the
+// formatting of grove dev. Without q.Since, only the files of the latest run
+// are read. It returns the number of files read and of lines that matched.
+func PrintLogs(w io.Writer, dir string, q LogQuery) (read, matched int, err error) {
+	files, err := logFiles(dir)
+	if err != nil {
+		return 0, 0, err
+	}
+	if q.Since.IsZero() && len(files) > 0 {
+		latest := logRun(files[len(files)-1])
+		files = slices.DeleteFunc(files, func(path string) bool {
+			return logRun(path) != latest
+		})
+	}
+
+	p := newLogPrinter(w, q)
+	for _, path := range files {
+		if !q.Since.IsZero() {
+			// A file last written before Since holds nothing newer.
+			if info, err := os.Stat(path); err == nil && info.ModTime().Before(q.Since) {
+				continue
+			}
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return read, p.matched, err
+		}
+		newRecordReader(f).read(p.print)
+		f.Close() //nolint:errcheck
+		read++
+	}
+	p.flush()
+	return read, p.matched, nil
+}
+
+// FollowLogs prints the lines appended to the latest log file in dir as they
+// arrive, moving on to the next file when grove dev starts one, until ctx is
+// done. Lines already in the file are skipped.
+func FollowLogs(ctx context.Context, w io.Writer, dir string, q LogQuery) error {
+	q.Since = time.Time{}
+	p := newLogPrinter(w, q)
+	defer p.flush()
+
+	var (
+		path string
+		f    *os.File
+		tail *recordReader
+	)
+	defer func() {
+		if f != nil {
+			f.Close() //nolint:errcheck
+		}
+	}()
+
+	tick := time.NewTicker(250 * time.Millisecond)
+	defer tick.Stop()
+
+	for {
+		if files, _ := logFiles(dir); len(files) > 0 && files[len(files)-1] != path {
+			next, err := os.Open(files[len(files)-1])
+			if err != nil {
+				return err
+			}
+			if f == nil {
+				// Start from the end of the file being written.
+				if _, err := next.Seek(0, io.SeekEnd); err != nil {
+					next.Close() //nolint:errcheck
+					return err
+				}
+			} else {
+				// Print what was written to the previous file before it
+				// was rotated.
+				tail.read(p.print)
+				f.Close() //nolint:errcheck
+			}
+			path, f, tail = files[len(files)-1], next, newRecordReader(next)
+		}
+
+		if tail != nil {
+			tail.read(p.print)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-tick.C:
+		}
+	}
+}
+
+// recordReader reads the records of a log file that may still be written.
+type recordReader struct {
+	r *bufio.Reader
+	// partial is a last line read before its newline was written.
+	partial []byte
+}
+
+func newRecordReader(r io.Reader) *recordReader {
+	return &recordReader{r: bufio.NewReader(r)}
+}
+
+// read calls fn for every complete record available, skipping malformed
+// lines. A partial last line is kept for the next call.
+func (rr *recordReader) read(fn func(LogRecord)) {
+	for {
+		line, err := rr.r.ReadBytes('\n')
+		rr.partial = append(rr.partial, line...)
+		if err != nil {
+			return
+		}
+
+		var rec LogRecord
+		if json.Unmarshal(rr.partial, &rec) == nil {
+			fn(rec)
+		}
+		rr.partial = rr.partial[:0]
+	}
+}
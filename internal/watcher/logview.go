@@ -0,0 +1,191 @@
+package watcher
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ── Log view ──────────────────────────────────────────────────────────────────
+
+// logLevels are the levels the structured app output can be filtered by, from
+// the most to the least verbose.
+var logLevels = []string{"debug", "info", "warn", "error"}
+
+// logView holds the display settings shared by the output writers of every
+// app. It is safe for concurrent use and may change while the apps run.
+type logView struct {
+	mu sync.RWMutex
+	// minLevel indexes logLevels: lines below it are hidden.
+	minLevel int
+	// fields, when set, are the only extra keys shown, in this order.
+	fields []string
+	// hide are extra keys never shown.
+	hide []string
+	// formats are the custom log formats, tried before builtinLogParsers.
+	formats []LogFormat
+	parsers []logParser
+}
+
+// logSettings are the log_* keys of grove.toml.
+type logSettings struct {
+	level   string
+	fields  []string
+	hide    []string
+	formats []LogFormat
+}
+
+func logSettingsOf(cfg Config) logSettings {
+	return logSettings{cfg.LogLevel, cfg.LogFields, cfg.LogHide, cfg.LogFormats}
+}
+
+// newLogView returns a view applying the log settings of cfg.
+func newLogView(cfg Config) *logView {
+	v := &logView{}
+	v.apply(cfg.LogLevel, cfg.LogFields, cfg.LogHide)
+	v.useFormats(cfg.LogFormats)
+	return v
+}
+
+// apply replaces the settings of the view. level must be valid or empty.
+func (v *logView) apply(level string, fields, hide []string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.minLevel = max(slices.Index(logLevels, strings.ToLower(level)), 0)
+	v.fields = fields
+	v.hide = hide
+}
+
+// settings returns the current settings of the view.
+func (v *logView) settings() logSettings {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return logSettings{logLevels[v.minLevel], v.fields, v.hide, v.formats}
+}
+
+// useFormats replaces the custom log formats. They must compile, as checked
+// by LoadConfig.
+func (v *logView) useFormats(formats []LogFormat) {
+	parsers, _ := compileLogFormats(formats)
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.formats = formats
+	v.parsers = parsers
+}
+
+// parse returns the entry logged by line, trying the custom formats and then
+// builtinLogParsers, or false when line is not structured.
+func (v *logView) parse(line string) (logEntry, bool) {
+	v.mu.RLock()
+	parsers := v.parsers
+	v.mu.RUnlock()
+
+	for _, p := range slices.Concat(parsers, builtinLogParsers) {
+		if e, ok := p.parse(line); ok {
+			return e, true
+		}
+	}
+	return logEntry{}, false
+}
+
+// shows reports whether a structured line logged at level is displayed.
+// Lines whose level is missing or unknown are always displayed.
+func (v *logView) shows(level string) bool {
+	rank := levelRank(level)
+	if rank < 0 {
+		return true
+	}
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return rank >= v.minLevel
+}
+
+// project returns the extra keys of a line to display, out of keys: the
+// configured fields present in keys, in their configured order, or else keys
+// without the hidden ones.
+func (v *logView) project(keys []string) []string {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	var out []string
+	if len(v.fields) > 0 {
+		for _, k := range v.fields {
+			if slices.Contains(keys, k) && !slices.Contains(v.hide, k) {
+				out = append(out, k)
+			}
+		}
+		return out
+	}
+	for _, k := range keys {
+		if !slices.Contains(v.hide, k) {
+			out = append(out, k)
+		}
+	}
+	return out
+}
+
+// cycleLevel raises the minimum level, wrapping from error back to debug,
+// and returns the new one.
+func (v *logView) cycleLevel() string {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.minLevel = (v.minLevel + 1) % len(logLevels)
+	return logLevels[v.minLevel]
+}
+
+// String describes the settings for the header, or returns "" when the view
+// shows everything.
+func (v *logView) String() string {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	var parts []string
+	if v.minLevel > 0 {
+		parts = append(parts, logLevels[v.minLevel]+" and above")
+	}
+	if len(v.fields) > 0 {
+		parts = append(parts, "fields "+strings.Join(v.fields, ", "))
+	}
+	if len(v.hide) > 0 {
+		parts = append(parts, "hide "+strings.Join(v.hide, ", "))
+	}
+	if len(v.formats) > 0 {
+		names := make([]string, len(v.formats))
+		for i, f := range v.formats {
+			names[i] = cmp.Or(f.Name, "#"+strconv.Itoa(i+1))
+		}
+		parts = append(parts, "formats "+strings.Join(names, ", "))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// ParseLogLevel validates a level name for log_level and grove logs --level.
+func ParseLogLevel(level string) error {
+	if level != "" && !slices.Contains(logLevels, strings.ToLower(level)) {
+		return fmt.Errorf(
+			"unknown log level %q (supported: %s)",
+			level, strings.Join(logLevels, ", "),
+		)
+	}
+	return nil
+}
+
+// levelRank returns the index in logLevels of a level as logged by slog, zap,
+// zerolog or logrus (including its four-letter text levels), or -1 when it is
+// not recognised.
+func levelRank(level string) int {
+	switch strings.ToUpper(level) {
+	case "TRACE", "TRAC", "DEBUG", "DEBU", "DBG":
+		return 0
+	case "INFO", "INF":
+		return 1
+	case "WARN", "WARNING", "WRN":
+		return 2
+	case "ERROR", "ERRO", "ERR", "FATAL", "FATA", "PANIC", "PANI", "DPANIC":
+		return 3
+	}
+	return -1
+}
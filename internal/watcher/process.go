@@ -1,7 +1,10 @@
 package watcher
 
 import (
+	"fmt"
 	"io"
+	"net"
+	"net/http"
 	"os"
 	"os/exec"
 	"strings"
@@ -9,33 +12,97 @@ import (
 	"time"
 )
 
-// appOut is the shared writer used by all child processes to format their
-// stdout/stderr through appOutputWriter before printing to the terminal.
-var appOut = newAppOutputWriter(os.Stdout)
-
 // Process manages the lifecycle of the running application binary.
 // It is safe for concurrent use — a sync.Mutex serialises all Restart calls
 // so that rapid file-change events never spawn duplicate processes.
 type Process struct {
 	mu       sync.Mutex
 	cmd      *exec.Cmd
-	waitCh   chan struct{}   // closed by the reaper goroutine when the process exits
-	lastDone <-chan struct{} // DoneCh of the most recently launched process
+	out      *appOutputWriter // formats the child's stdout/stderr
+	ready    readiness        // decides when a new process counts as up
+	waitCh   chan struct{}    // closed by the reaper goroutine when the process exits
+	lastDone <-chan struct{}  // DoneCh of the most recently launched process
+	stdin    io.Reader        // the child's stdin; nil while grove dev reads the keyboard
+}
+
+// newProcess returns a Process whose output is formatted through out.
+func newProcess(out *appOutputWriter, ready readiness) *Process {
+	return &Process{out: out, ready: ready, stdin: os.Stdin}
+}
+
+// readiness decides when a freshly started process counts as up.
+type readiness struct {
+	// healthURL is polled until it answers 2xx.
+	healthURL string
+
+	// dialAddr, used without a health URL, is dialled until it accepts a
+	// TCP connection: the dev proxy must not forward before the app listens.
+	dialAddr string
+
+	// timeout bounds the health check.
+	timeout time.Duration
+
+	// window is the stabilisation window used without a health URL or a
+	// dial address.
+	window time.Duration
+}
+
+// checked reports whether readiness is probed rather than assumed after
+// window.
+func (r readiness) checked() bool {
+	return r.healthURL != "" || r.dialAddr != ""
 }
 
+// probe reports whether the process is up, by one health request or dial.
+func (r readiness) probe(client *http.Client) bool {
+	if r.healthURL == "" {
+		conn, err := net.DialTimeout("tcp", r.dialAddr, time.Second)
+		if err != nil {
+			return false
+		}
+		conn.Close() //nolint:errcheck
+		return true
+	}
+
+	resp, err := client.Get(r.healthURL)
+	if err != nil {
+		// Not listening yet.
+		return false
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close() //nolint:errcheck
+	return resp.StatusCode >= 200 && resp.StatusCode < 300
+}
+
+// String describes what readiness waits for: "" when it only waits for
+// window.
+func (r readiness) String() string {
+	if r.healthURL != "" {
+		return r.healthURL
+	}
+	return r.dialAddr
+}
+
+// healthPollInterval is the delay between two health check requests.
+const healthPollInterval = 100 * time.Millisecond
+
 // RestartResult is returned by Restart and lets the caller observe whether the
-// newly launched process is still alive after a short stabilisation window.
+// newly launched process came up: it answered its health check or, without
+// one, is still alive after the stabilisation window.
 type RestartResult struct {
-	// ReadyCh is closed once the stabilisation window has elapsed AND the
-	// process is still running. If the process crashed before the window
-	// expired, ReadyCh is never closed — CrashCh is closed instead.
+	// ReadyCh is closed once the process is up. If it exits first, ReadyCh
+	// is never closed — CrashCh is closed instead.
 	ReadyCh <-chan struct{}
 
-	// CrashCh is closed if the process exits within the stabilisation window
-	// (i.e. a fast crash / startup panic). The caller should treat this as a
-	// failed start rather than a clean launch.
+	// CrashCh is closed if the process exits before it is up (i.e. a
+	// startup panic, or a failed connection during boot). The caller should
+	// treat this as a failed start rather than a clean launch.
 	CrashCh <-chan struct{}
 
+	// TimeoutCh is closed if the process is still running but has not
+	// answered its health check within the timeout.
+	TimeoutCh <-chan struct{}
+
 	// DoneCh mirrors the internal waitCh: it is closed when the process has
 	// fully exited and all pipe output has been flushed. The caller can wait
 	// on this channel to ensure all output (including panic dumps) has been
@@ -44,7 +111,7 @@ type RestartResult struct {
 }
 
 // Restart stops the currently running process (if any) and starts a new one
-// from the binary at bin.
+// from the binary at bin, with the environment env (nil inherits grove's).
 //
 // Shutdown sequence:
 //  1. Send os.Interrupt (SIGINT on Unix) so the app can clean up.
@@ -57,7 +124,7 @@ type RestartResult struct {
 //
 // The returned RestartResult lets the caller distinguish between a healthy
 // start and an immediate crash (e.g. a startup panic).
-func (p *Process) Restart(bin string) (RestartResult, error) {
+func (p *Process) Restart(bin string, env []string) (RestartResult, error) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
@@ -103,7 +170,8 @@ func (p *Process) Restart(bin string) (RestartResult, error) {
 	if err != nil {
 		return RestartResult{}, err
 	}
-	cmd.Stdin = os.Stdin
+	cmd.Stdin = p.stdin
+	cmd.Env = env
 
 	if err := cmd.Start(); err != nil {
 		return RestartResult{}, err
@@ -119,56 +187,88 @@ func (p *Process) Restart(bin string) (RestartResult, error) {
 	// We drain stdout and stderr in dedicated goroutines and wait for both to
 	// finish before calling Flush(). This ensures every byte written by the
 	// child — including a panic dumped by the Go runtime right before exit —
-	// has been processed by appOut before we declare the process done.
+	// has been processed by p.out before we declare the process done.
+	out := p.out
 	go func() {
 		var wg sync.WaitGroup
 		wg.Add(2)
 
 		go func() {
 			defer wg.Done()
-			_, _ = io.Copy(appOut, stdoutPipe)
+			_, _ = io.Copy(out, stdoutPipe)
 		}()
 		go func() {
 			defer wg.Done()
-			_, _ = io.Copy(appOut, stderrPipe)
+			_, _ = io.Copy(out, stderrPipe)
 		}()
 
 		// Wait for both pipes to be fully drained (EOF), then wait for the
 		// process to exit, then flush any partial panic buffer.
 		wg.Wait()
 		_ = cmd.Wait()
-		appOut.Flush()
+		out.Flush()
 		close(waitCh)
 	}()
 
 	// ── 4. Build result channels ──────────────────────────────────────────────
-	// stabilisationWindow is how long we wait before declaring the process
-	// healthy. Startup panics in Go programs typically print and exit within
-	// a few milliseconds; 500 ms is a comfortable margin that still feels
-	// instant to the developer.
-	const stabilisationWindow = 500 * time.Millisecond
-
 	readyCh := make(chan struct{})
 	crashCh := make(chan struct{})
+	timeoutCh := make(chan struct{})
 
-	go func() {
+	if !p.ready.checked() {
+		// The stabilisation window is how long we wait before declaring the
+		// process healthy. Startup panics in Go programs typically print and
+		// exit within a few milliseconds; the 500 ms default is a
+		// comfortable margin that still feels instant to the developer.
+		go func() {
+			select {
+			case <-waitCh:
+				// Process exited within the stabilisation window — it's a crash.
+				close(crashCh)
+			case <-time.After(p.ready.window):
+				// Process is still running after the window — declare it ready.
+				close(readyCh)
+			}
+		}()
+	} else {
+		go pollHealth(p.ready, waitCh, readyCh, crashCh, timeoutCh)
+	}
+
+	result := RestartResult{
+		ReadyCh:   readyCh,
+		CrashCh:   crashCh,
+		TimeoutCh: timeoutCh,
+		DoneCh:    waitCh,
+	}
+	p.lastDone = waitCh
+	return result, nil
+}
+
+// pollHealth probes r until the process is up, closing readyCh. It closes
+// crashCh instead if the process exits first (waitCh), and timeoutCh if
+// r.timeout elapses.
+func pollHealth(r readiness, waitCh <-chan struct{}, readyCh, crashCh, timeoutCh chan struct{}) {
+	client := &http.Client{Timeout: time.Second}
+	deadline := time.After(r.timeout)
+	tick := time.NewTicker(healthPollInterval)
+	defer tick.Stop()
+
+	for {
 		select {
 		case <-waitCh:
-			// Process exited within the stabilisation window — it's a crash.
 			close(crashCh)
-		case <-time.After(stabilisationWindow):
-			// Process is still running after the window — declare it ready.
-			close(readyCh)
+			return
+		case <-deadline:
+			close(timeoutCh)
+			return
+		case <-tick.C:
 		}
-	}()
 
-	result := RestartResult{
-		ReadyCh: readyCh,
-		CrashCh: crashCh,
-		DoneCh:  waitCh,
+		if r.probe(client) {
+			close(readyCh)
+			return
+		}
 	}
-	p.lastDone = waitCh
-	return result, nil
 }
 
 // WaitDone waits for the most recently launched process's output to be fully
@@ -196,6 +296,23 @@ func (p *Process) WaitDone() {
 	}
 }
 
+// Signal sends sig to the running process. It is an error when no process
+// is running.
+func (p *Process) Signal(sig os.Signal) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.cmd == nil || p.cmd.Process == nil {
+		return fmt.Errorf("the app is not running")
+	}
+	select {
+	case <-p.waitCh:
+		return fmt.Errorf("the app is not running")
+	default:
+	}
+	return p.cmd.Process.Signal(sig)
+}
+
 // Stop sends an interrupt signal to the running process and waits for it to
 // exit. It is intended for clean shutdown (e.g. when the user hits Ctrl-C).
 // If the process has already exited Stop is a no-op.
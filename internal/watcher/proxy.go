@@ -0,0 +1,272 @@
+package watcher
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ── Dev proxy ─────────────────────────────────────────────────────────────────
+
+// proxyHoldTimeout bounds how long a request is held while the app rebuilds
+// before it is answered with 503.
+const proxyHoldTimeout = 60 * time.Second
+
+// proxyState is what the proxy does with incoming requests.
+type proxyState int
+
+const (
+	// proxyHolding holds requests until the rebuild cycle ends.
+	proxyHolding proxyState = iota
+	// proxyForwarding forwards requests to the app.
+	proxyForwarding
+	// proxyFailed answers requests with the error page.
+	proxyFailed
+)
+
+// devProxy is the reverse proxy grove dev runs on proxy_port in front of the
+// first app. While the app is being rebuilt and restarted it holds incoming
+// requests and replays them once the app is ready, so clients never see
+// "connection refused"; when the cycle fails it serves the errors instead.
+type devProxy struct {
+	addr   string
+	target *url.URL
+	rp     *httputil.ReverseProxy
+	srv    *http.Server
+	// live reloads the pages served through the proxy; nil when
+	// live_reload is off.
+	live *liveReload
+
+	mu    sync.Mutex
+	state proxyState
+	// gate is closed when the proxy leaves proxyHolding.
+	gate chan struct{}
+	// title and output describe the failure served in proxyFailed.
+	title  string
+	output string
+}
+
+// newDevProxy returns a proxy listening on port and forwarding to appPort on
+// the loopback interface, injecting the live reload script into HTML pages
+// when live is set. It starts in proxyHolding, as grove dev begins with a
+// build.
+func newDevProxy(port, appPort int, live bool) *devProxy {
+	target := &url.URL{Scheme: "http", Host: net.JoinHostPort("127.0.0.1", strconv.Itoa(appPort))}
+
+	p := &devProxy{
+		addr:   ":" + strconv.Itoa(port),
+		target: target,
+		state:  proxyHolding,
+		gate:   make(chan struct{}),
+	}
+
+	p.rp = httputil.NewSingleHostReverseProxy(target)
+	// Flush immediately so streamed responses (SSE, chunked) are not held.
+	p.rp.FlushInterval = -1
+	p.rp.ErrorHandler = func(w http.ResponseWriter, r *http.Request, err error) {
+		p.writeError(w, r, http.StatusBadGateway,
+			"App unreachable",
+			"The proxy could not reach the app on "+target.Host+":\n\n"+err.Error(),
+		)
+	}
+	if live {
+		p.live = newLiveReload()
+		p.rp.ModifyResponse = p.live.inject
+		// Ask for uncompressed responses so the script can be injected.
+		director := p.rp.Director
+		p.rp.Director = func(r *http.Request) {
+			director(r)
+			r.Header.Del("Accept-Encoding")
+		}
+	}
+	p.srv = &http.Server{Addr: p.addr, Handler: p}
+
+	return p
+}
+
+// Start listens on the proxy port in the background.
+func (p *devProxy) Start() error {
+	ln, err := net.Listen("tcp", p.addr)
+	if err != nil {
+		return err
+	}
+	go func() { _ = p.srv.Serve(ln) }()
+	return nil
+}
+
+// The methods below are no-ops on a nil *devProxy, so callers need not check
+// whether the proxy is enabled.
+
+// Close stops the proxy, dropping held requests.
+func (p *devProxy) Close() {
+	if p != nil {
+		_ = p.srv.Close()
+	}
+}
+
+// Hold makes the proxy hold incoming requests until Open or Fail.
+func (p *devProxy) Hold() {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.state != proxyHolding {
+		p.state = proxyHolding
+		p.gate = make(chan struct{})
+	}
+}
+
+// Open forwards held and future requests to the app and reloads the pages
+// connected for live reload.
+func (p *devProxy) Open() {
+	p.set(proxyForwarding, "", "")
+	p.Reload()
+}
+
+// Fail answers held and future requests with an error page showing title
+// and output, e.g. the compiler errors, and reloads the connected pages so
+// they show it.
+func (p *devProxy) Fail(title, output string) {
+	p.set(proxyFailed, title, output)
+	p.Reload()
+}
+
+// Reload makes the pages connected for live reload reload.
+func (p *devProxy) Reload() {
+	if p.LiveReload() {
+		p.live.Reload()
+	}
+}
+
+// ReloadCSS makes the pages connected for live reload swap the stylesheets
+// named in names.
+func (p *devProxy) ReloadCSS(names []string) {
+	if p.LiveReload() {
+		p.live.ReloadCSS(names)
+	}
+}
+
+// LiveReload reports whether the proxy runs and injects the live reload
+// script.
+func (p *devProxy) LiveReload() bool {
+	return p != nil && p.live != nil
+}
+
+func (p *devProxy) set(state proxyState, title, output string) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.state == proxyHolding {
+		close(p.gate)
+	}
+	p.state, p.title, p.output = state, title, output
+}
+
+// ServeHTTP holds the request while the app is rebuilding, then forwards it
+// or answers with the error page.
+func (p *devProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// The live reload endpoints answer even while the app is down.
+	if p.live != nil && strings.HasPrefix(r.URL.Path, "/__grove/") {
+		p.live.ServeHTTP(w, r)
+		return
+	}
+
+	p.mu.Lock()
+	state, gate := p.state, p.gate
+	p.mu.Unlock()
+
+	if state == proxyHolding {
+		select {
+		case <-gate:
+		case <-r.Context().Done():
+			return
+		case <-time.After(proxyHoldTimeout):
+			p.writeError(w, r, http.StatusServiceUnavailable,
+				"Still rebuilding",
+				"The app did not come back within "+proxyHoldTimeout.String()+".",
+			)
+			return
+		}
+		p.mu.Lock()
+		state = p.state
+		p.mu.Unlock()
+	}
+
+	if state == proxyFailed {
+		p.mu.Lock()
+		title, output := p.title, p.output
+		p.mu.Unlock()
+		p.writeError(w, r, http.StatusBadGateway, title, output)
+		return
+	}
+
+	p.rp.ServeHTTP(w, r)
+}
+
+// writeError answers with an HTML page for browsers and JSON otherwise.
+func (p *devProxy) writeError(w http.ResponseWriter, r *http.Request, status int, title, output string) {
+	w.Header().Set("Cache-Control", "no-store")
+
+	if !strings.Contains(r.Header.Get("Accept"), "text/html") {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"error":  title,
+			"output": output,
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	_ = errorPage.Execute(w, struct {
+		Title      string
+		Output     string
+		LiveReload bool
+	}{title, output, p.live != nil})
+}
+
+// errorPage is the HTML served while the app cannot answer.
+var errorPage = template.Must(template.New("error").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}} · grove dev</title>
+<style>
+  body { margin: 0; padding: 3rem; background: #16161c; color: #e4e4ec; font: 15px/1.5 ui-sans-serif, system-ui, sans-serif; }
+  h1 { margin: 0 0 1.5rem; font-size: 1.25rem; }
+  h1 span { background: #c33737; color: #fff; padding: .2rem .6rem; margin-right: .6rem; border-radius: 3px; font-size: .85rem; }
+  pre { background: #1f1f27; border-left: 3px solid #dc3c3c; padding: 1rem 1.25rem; overflow-x: auto; color: #f08c8c; font: 13px/1.6 ui-monospace, monospace; }
+  p { color: #82828f; }
+</style>
+</head>
+<body>
+<h1><span>GROVE DEV</span>{{.Title}}</h1>
+{{if .Output}}<pre>{{.Output}}</pre>{{end}}
+<p>Save a file to rebuild.</p>
+{{if .LiveReload}}<script src="/__grove/livereload.js"></script>{{end}}
+</body>
+</html>
+`))
+
+// String describes the proxy for the header.
+func (p *devProxy) String() string {
+	s := fmt.Sprintf("http://localhost%s → %s", p.addr, p.target.Host)
+	if p.live != nil {
+		s += " · live reload"
+	}
+	return s
+}
@@ -0,0 +1,172 @@
+package watcher
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+	"syscall"
+)
+
+// ── Watch rules ───────────────────────────────────────────────────────────────
+
+// Action is what grove dev does once a changed file has been handled.
+type Action string
+
+const (
+	// ActionBuild rebuilds and restarts every app (the default).
+	ActionBuild Action = "build"
+	// ActionRestart restarts every app without rebuilding it.
+	ActionRestart Action = "restart"
+	// ActionSignal sends WatchRule.Signal to every running app.
+	ActionSignal Action = "signal"
+	// ActionReload reloads the pages open through the dev proxy, swapping
+	// stylesheets in place when only .css files changed.
+	ActionReload Action = "reload"
+	// ActionNone does nothing beyond running WatchRule.Command.
+	ActionNone Action = "none"
+)
+
+// actionRank orders actions so a debounce window that mixes several rules
+// performs the strongest one: a rebuild restarts, and a restart supersedes a
+// signal. Every restart reloads the pages, so a reload is the weakest.
+var actionRank = map[Action]int{
+	ActionNone:    0,
+	ActionReload:  1,
+	ActionSignal:  2,
+	ActionRestart: 3,
+	ActionBuild:   4,
+}
+
+// WatchRule overrides what a change to the files matching Pattern triggers.
+// Rules are declared as [[dev.watch]] tables and tried in order; the first
+// match wins. Files matching no rule fall back to Extensions and a rebuild.
+//
+//	[[dev.watch]]
+//	pattern = "web/templates/**/*.html"
+//	action  = "restart"
+//
+//	[[dev.watch]]
+//	pattern = "**/*.templ"
+//	command = "templ generate"
+//	action  = "none"
+type WatchRule struct {
+	// Pattern is a slash-separated glob relative to Root. "**" matches any
+	// number of directories; a pattern without a slash matches the file
+	// name in any directory.
+	Pattern string `toml:"pattern"`
+
+	// Command runs in Root before Action, e.g. "sqlc generate". A failing
+	// command cancels the action.
+	Command string `toml:"command"`
+
+	// Action is build (default), restart, signal, reload or none.
+	Action Action `toml:"action"`
+
+	// Signal is sent by the signal action: SIGHUP (default), SIGINT,
+	// SIGQUIT or SIGTERM.
+	Signal string `toml:"signal"`
+}
+
+// signalNames lists the signals a rule may send. Only signals defined on
+// every platform are accepted.
+var signalNames = map[string]syscall.Signal{
+	"SIGHUP":  syscall.SIGHUP,
+	"SIGINT":  syscall.SIGINT,
+	"SIGQUIT": syscall.SIGQUIT,
+	"SIGTERM": syscall.SIGTERM,
+}
+
+// resolveWatchRules validates the [[dev.watch]] tables and fills in their
+// defaults.
+func resolveWatchRules(rules []WatchRule) ([]WatchRule, error) {
+	out := make([]WatchRule, 0, len(rules))
+	for i, rule := range rules {
+		if rule.Pattern == "" {
+			return nil, fmt.Errorf("[[dev.watch]] #%d has no pattern", i+1)
+		}
+		if _, err := path.Match(strings.ReplaceAll(rule.Pattern, "**", "*"), ""); err != nil {
+			return nil, fmt.Errorf("[[dev.watch]] %q: invalid pattern: %w", rule.Pattern, err)
+		}
+
+		rule.Command = strings.TrimSpace(rule.Command)
+		if rule.Action == "" {
+			rule.Action = ActionBuild
+		}
+		if _, ok := actionRank[rule.Action]; !ok {
+			return nil, fmt.Errorf(
+				"[[dev.watch]] %q: unknown action %q (supported: build, restart, signal, reload, none)",
+				rule.Pattern, rule.Action,
+			)
+		}
+
+		if rule.Signal == "" {
+			rule.Signal = "SIGHUP"
+		}
+		rule.Signal = strings.ToUpper(rule.Signal)
+		if !strings.HasPrefix(rule.Signal, "SIG") {
+			rule.Signal = "SIG" + rule.Signal
+		}
+		if _, ok := signalNames[rule.Signal]; !ok {
+			return nil, fmt.Errorf(
+				"[[dev.watch]] %q: unsupported signal %q (supported: SIGHUP, SIGINT, SIGQUIT, SIGTERM)",
+				rule.Pattern, rule.Signal,
+			)
+		}
+		out = append(out, rule)
+	}
+	return out, nil
+}
+
+// matchRule returns the first rule whose pattern matches rel, a
+// slash-separated path relative to Root.
+func matchRule(rules []WatchRule, rel string) (WatchRule, bool) {
+	for _, rule := range rules {
+		if matchGlob(rule.Pattern, rel) {
+			return rule, true
+		}
+	}
+	return WatchRule{}, false
+}
+
+// matchGlob reports whether the slash-separated path name matches pattern.
+// Segments are matched with path.Match, and a "**" segment matches zero or
+// more directories. A pattern without a slash is matched against the base
+// name only, so "*.html" matches templates at any depth.
+func matchGlob(pattern, name string) bool {
+	if !strings.Contains(pattern, "/") {
+		ok, _ := path.Match(pattern, path.Base(name))
+		return ok
+	}
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+// relPath returns p relative to root with forward slashes, or p cleaned when
+// it lies outside root.
+func relPath(root, p string) string {
+	if rel, err := filepath.Rel(root, p); err == nil && !strings.HasPrefix(rel, "..") {
+		return filepath.ToSlash(rel)
+	}
+	return filepath.ToSlash(filepath.Clean(p))
+}
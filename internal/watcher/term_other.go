@@ -0,0 +1,13 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd)
+
+package watcher
+
+import "errors"
+
+// Keyboard controls need a Unix terminal.
+
+func isTerminal(int) bool { return false }
+
+func enableCBreak(int) (func(), error) {
+	return nil, errors.New("keyboard controls are not supported on this platform")
+}
@@ -2,12 +2,18 @@ package watcher
 
 import (
 	"bytes"
-	"encoding/json"
+	"context"
+	"crypto/sha256"
 	"fmt"
+	"io"
+	"net"
 	"os"
 	"os/exec"
 	"os/signal"
 	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"syscall"
@@ -19,14 +25,16 @@ import (
 // ── ANSI helpers (self-contained so this package has no dep on package main) ──
 
 const (
-	ansiReset  = "\033[0m"
-	ansiBold   = "\033[1m"
-	ansiDim    = "\033[2m"
-	ansiRed    = "\033[38;2;220;60;60m"
-	ansiGreen  = "\033[38;2;40;210;90m"
-	ansiYellow = "\033[38;2;230;200;40m"
-	ansiCyan   = "\033[38;2;80;220;220m"
-	ansiGray   = "\033[38;2;130;130;145m"
+	ansiReset   = "\033[0m"
+	ansiBold    = "\033[1m"
+	ansiDim     = "\033[2m"
+	ansiRed     = "\033[38;2;220;60;60m"
+	ansiGreen   = "\033[38;2;40;210;90m"
+	ansiYellow  = "\033[38;2;230;200;40m"
+	ansiCyan    = "\033[38;2;80;220;220m"
+	ansiGray    = "\033[38;2;130;130;145m"
+	ansiMagenta = "\033[38;2;210;110;220m"
+	ansiBlue    = "\033[38;2;90;150;240m"
 
 	ansiBgGreen  = "\033[48;2;40;180;80m\033[38;2;255;255;255m"
 	ansiBgRed    = "\033[48;2;195;55;55m\033[38;2;255;255;255m"
@@ -48,29 +56,130 @@ func logDev(msg string) { fmt.Println("  " + msg) }
 // child-process manager.  Create one with New and call Start to begin the loop.
 type Watcher struct {
 	cfg  Config
-	proc *Process
+	apps []*appRunner
 
 	fsw *fsnotify.Watcher
 
-	// mu guards the debounce timer so that concurrent fsnotify callbacks never
-	// schedule two simultaneous rebuilds.
+	// hashes holds the last seen content hash of every handled file, so a
+	// write that leaves a file unchanged — typically a generator run by a
+	// pre_build hook rewriting its output — does not trigger another run.
+	hashMu sync.Mutex
+	hashes map[string][sha256.Size]byte
+
+	// mu guards the debounce timer and the pending changes so that
+	// concurrent fsnotify callbacks never schedule two simultaneous rebuilds.
 	mu       sync.Mutex
 	debounce *time.Timer
+	// pending maps each file changed in the current debounce window to the
+	// rule handling it.
+	pending map[string]WatchRule
+	// cancelBuild cancels the builds in flight, if any, so a newer change
+	// starts over instead of queueing behind them.
+	cancelBuild context.CancelFunc
+	// forceBuild makes the next run rebuild even if its changes alone would
+	// not: the last builds were cancelled, or the r key was pressed.
+	forceBuild bool
+
+	// proxy holds requests to the first app while it rebuilds; nil unless
+	// proxy_port is set.
+	proxy *devProxy
 
 	// rebuildCh decouples the fsnotify goroutine from the rebuild goroutine so
 	// that a slow build never blocks the watcher event loop.
 	rebuildCh chan struct{}
+
+	// tasks are run by the rebuild worker between two runs, e.g. the test
+	// suite started with the t key.
+	tasks chan func()
+
+	// view holds the display settings of the apps' output.
+	view *logView
+	// logFile are the log settings grove.toml held when last read.
+	logFile logSettings
 }
 
 // New returns a ready-to-use Watcher.  Call Start to begin watching.
 func New(cfg Config) *Watcher {
-	return &Watcher{
+	view := newLogView(cfg)
+	w := &Watcher{
 		cfg:       cfg,
-		proc:      &Process{},
+		logFile:   logSettingsOf(cfg),
+		apps:      newAppRunners(cfg, view),
+		hashes:    map[string][sha256.Size]byte{},
+		pending:   map[string]WatchRule{},
 		rebuildCh: make(chan struct{}, 1),
+		tasks:     make(chan func(), 1),
+		view:      view,
+	}
+	// cfg may carry --log-* flags; reloadLogView compares against the file.
+	if file, err := LoadConfig(); err == nil {
+		w.logFile = logSettingsOf(file)
+	}
+	if cfg.ProxyPort > 0 {
+		w.proxy = newDevProxy(cfg.ProxyPort, cfg.AppPort, cfg.LiveReload)
 	}
+	return w
 }
 
+// appRunner supervises one app: its process and the writers its build and
+// runtime output go through.
+type appRunner struct {
+	app  App
+	proc *Process
+	out  *appOutputWriter
+	// stdout and stderr prefix every line with the app's label.
+	stdout io.Writer
+	stderr io.Writer
+}
+
+// appColours are assigned to app labels in declaration order.
+var appColours = []string{ansiCyan, ansiMagenta, ansiYellow, ansiBlue, ansiGreen}
+
+// newAppRunners returns one runner per app. With a single app the output is
+// left unprefixed, exactly as before multi-app support.
+func newAppRunners(cfg Config, view *logView) []*appRunner {
+	apps := cfg.Apps
+
+	width := 0
+	for _, app := range apps {
+		width = max(width, len(app.Name))
+	}
+
+	runners := make([]*appRunner, 0, len(apps))
+	for i, app := range apps {
+		label := ""
+		if len(apps) > 1 {
+			colour := appColours[i%len(appColours)]
+			label = colour + ansiBold + fmt.Sprintf("%-*s", width, app.Name) +
+				ansiReset + " " + ansiGray + "│" + ansiReset
+		}
+		stdout := &prefixWriter{w: os.Stdout, prefix: label, atStart: true}
+		out := newAppOutputWriter(stdout, view)
+		out.app = app.Name
+		ready := readiness{
+			healthURL: app.HealthURL,
+			timeout:   cfg.HealthTimeout,
+			window:    cfg.ReadyWindow,
+		}
+		// The dev proxy forwards to the first app: without a health URL it
+		// is up once it listens on app_port.
+		if i == 0 && cfg.ProxyPort > 0 && app.HealthURL == "" {
+			ready.dialAddr = net.JoinHostPort("127.0.0.1", strconv.Itoa(cfg.AppPort))
+		}
+		runners = append(runners, &appRunner{
+			app:    app,
+			proc:   newProcess(out, ready),
+			out:    out,
+			stdout: stdout,
+			stderr: &prefixWriter{w: os.Stderr, prefix: label, atStart: true},
+		})
+	}
+	return runners
+}
+
+// log prints msg through the app's prefixed stdout.
+func (a *appRunner) log(msg string) { fmt.Fprintln(a.stdout, "  "+msg) }
+
 // Start performs an initial build+run, then enters the fsnotify event loop.
 // It blocks until the user sends SIGINT / SIGTERM.
 func (w *Watcher) Start() error {
@@ -79,6 +188,16 @@ func (w *Watcher) Start() error {
 		return fmt.Errorf("cannot create tmp_dir %q: %w", w.cfg.TmpDir, err)
 	}
 
+	// ── Log capture ──────────────────────────────────────────────────────────
+	if rec, err := newLogRecorder(LogDir); err != nil {
+		logDev(ansiYellow + "⚠  Cannot capture logs in " + LogDir + ": " + err.Error() + ansiReset)
+	} else {
+		defer rec.Close()
+		for _, a := range w.apps {
+			a.out.recorder = rec
+		}
+	}
+
 	// ── Set up the fsnotify watcher ──────────────────────────────────────────
 	fsw, err := fsnotify.NewWatcher()
 	if err != nil {
@@ -96,8 +215,23 @@ func (w *Watcher) Start() error {
 		}
 	}
 
+	// ── Dev proxy ────────────────────────────────────────────────────────────
+	if w.proxy != nil {
+		if err := w.proxy.Start(); err != nil {
+			logDev(
+				ansiYellow + "⚠  Cannot start the dev proxy: " + err.Error() + ansiReset,
+			)
+			w.proxy = nil
+		}
+		defer w.proxy.Close()
+	}
+
+	// ── Keyboard ──────────────────────────────────────────────────────────────
+	keyCh, restore := w.startKeyboard()
+	defer restore()
+
 	// ── Initial build + launch ───────────────────────────────────────────────
-	w.printHeader()
+	w.printHeader(keyCh != nil)
 	w.runRebuild()
 
 	// ── Signal handling ───────────────────────────────────────────────────────
@@ -107,10 +241,16 @@ func (w *Watcher) Start() error {
 
 	// ── Rebuild worker ────────────────────────────────────────────────────────
 	// A dedicated goroutine drains rebuildCh so the fsnotify loop is never
-	// blocked by a long compilation.
+	// blocked by a long compilation. Tasks share it so they never overlap a
+	// build.
 	go func() {
-		for range w.rebuildCh {
-			w.runRebuild()
+		for {
+			select {
+			case <-w.rebuildCh:
+				w.runPending(w.takePending())
+			case task := <-w.tasks:
+				task()
+			}
 		}
 	}()
 
@@ -134,8 +274,8 @@ func (w *Watcher) Start() error {
 				}
 			}
 
-			if w.shouldHandle(event) {
-				w.scheduleRebuild()
+			if rule, ok := w.shouldHandle(event); ok {
+				w.scheduleRebuild(event.Name, rule)
 			}
 
 		case err, ok := <-fsw.Errors:
@@ -144,52 +284,137 @@ func (w *Watcher) Start() error {
 			}
 			logDev(ansiYellow + "⚠  Watcher error: " + err.Error() + ansiReset)
 
+		case key := <-keyCh:
+			if quit := w.handleKey(key); quit {
+				w.stop()
+				return nil
+			}
+
 		case <-sigCh:
-			fmt.Println()
-			logDev(ansiGray + "Stopping application…" + ansiReset)
-			w.proc.Stop()
-			fmt.Println()
-			logDev(
-				badge(
-					ansiBgGrove,
-					"GROVE DEV",
-				) + "  " + ansiGray + "stopped." + ansiReset,
-			)
-			fmt.Println()
+			w.stop()
 			return nil
 		}
 	}
 }
 
+// stop cancels the builds in flight and stops every app.
+func (w *Watcher) stop() {
+	fmt.Println()
+	logDev(ansiGray + "Stopping application…" + ansiReset)
+	w.mu.Lock()
+	if w.cancelBuild != nil {
+		w.cancelBuild()
+	}
+	w.mu.Unlock()
+	var wg sync.WaitGroup
+	for _, a := range w.apps {
+		wg.Add(1)
+		go func(a *appRunner) {
+			defer wg.Done()
+			a.proc.Stop()
+		}(a)
+	}
+	wg.Wait()
+	fmt.Println()
+	logDev(
+		badge(
+			ansiBgGrove,
+			"GROVE DEV",
+		) + "  " + ansiGray + "stopped." + ansiReset,
+	)
+	fmt.Println()
+}
+
 // ── Filtering ─────────────────────────────────────────────────────────────────
 
-// shouldHandle returns true when event should trigger a rebuild:
+// shouldHandle reports whether event should trigger a run, and the rule
+// describing it:
 //   - Op must be Write or Create (Rename/Remove/Chmod are ignored).
 //   - The path must not be inside an excluded directory.
 //   - The filename must not end in _test.go (test files never trigger a rebuild).
-//   - The file extension must be in the configured allow-list.
-func (w *Watcher) shouldHandle(event fsnotify.Event) bool {
+//   - The path must match a [[dev.watch]] rule, or its extension must be in
+//     the configured allow-list, which triggers a rebuild.
+func (w *Watcher) shouldHandle(event fsnotify.Event) (WatchRule, bool) {
 	if !event.Has(fsnotify.Write) && !event.Has(fsnotify.Create) {
-		return false
+		return WatchRule{}, false
 	}
 
 	if w.isExcluded(event.Name) {
-		return false
+		return WatchRule{}, false
 	}
 
+	return w.ruleFor(event.Name)
+}
+
+// ruleFor returns the rule handling changes to the file at p, if any.
+func (w *Watcher) ruleFor(p string) (WatchRule, bool) {
 	// Test files must never cause a rebuild regardless of their location.
-	if strings.HasSuffix(filepath.Base(event.Name), "_test.go") {
-		return false
+	if strings.HasSuffix(filepath.Base(p), "_test.go") {
+		return WatchRule{}, false
+	}
+
+	// grove.toml is only re-read for its log settings, see reloadLogView.
+	if isConfigFile(p) {
+		return WatchRule{Pattern: configFile, Action: ActionNone}, true
 	}
 
-	ext := filepath.Ext(event.Name)
+	if rule, ok := matchRule(w.cfg.Watch, relPath(w.cfg.Root, p)); ok {
+		return rule, true
+	}
+
+	// Env files are read at start, so a change only needs a restart.
+	if w.isEnvFile(p) {
+		return WatchRule{Pattern: relPath(w.cfg.Root, p), Action: ActionRestart}, true
+	}
+
+	ext := filepath.Ext(p)
 	for _, allowed := range w.cfg.Extensions {
 		if ext == allowed {
-			return true
+			return WatchRule{Action: ActionBuild}, true
 		}
 	}
 
-	return false
+	// Stylesheets served from disk only need the pages to pick them up.
+	if ext == ".css" && w.proxy.LiveReload() {
+		return WatchRule{Pattern: "*.css", Action: ActionReload}, true
+	}
+
+	return WatchRule{}, false
+}
+
+// contentDiffers reports whether the content of the file at p differs from
+// the last one seen, without recording it. Unreadable files count as changed.
+func (w *Watcher) contentDiffers(p string) bool {
+	raw, err := os.ReadFile(p)
+	if err != nil {
+		return true
+	}
+	sum := sha256.Sum256(raw)
+
+	w.hashMu.Lock()
+	defer w.hashMu.Unlock()
+	prev, ok := w.hashes[filepath.Clean(p)]
+	return !ok || prev != sum
+}
+
+// contentChanged records the content hash of the file at p and reports
+// whether it differs from the last one seen. Unreadable files count as
+// changed.
+func (w *Watcher) contentChanged(p string) bool {
+	raw, err := os.ReadFile(p)
+	if err != nil {
+		return true
+	}
+	key := filepath.Clean(p)
+	sum := sha256.Sum256(raw)
+
+	w.hashMu.Lock()
+	defer w.hashMu.Unlock()
+	if prev, ok := w.hashes[key]; ok && prev == sum {
+		return false
+	}
+	w.hashes[key] = sum
+	return true
 }
 
 // isExcluded returns true when any path component of p matches an entry in
@@ -209,12 +434,85 @@ func (w *Watcher) isExcluded(p string) bool {
 
 // ── Debounce ──────────────────────────────────────────────────────────────────
 
-// scheduleRebuild arms (or resets) the debounce timer.  When the timer fires
-// it sends a single token on rebuildCh, which the rebuild worker drains.
-func (w *Watcher) scheduleRebuild() {
+// pendingRun accumulates what the changes of one debounce window require.
+type pendingRun struct {
+	// commands are the rule commands to run first, without duplicates.
+	commands []string
+	// action is the strongest action requested.
+	action Action
+	// signals are the signals to send when action is ActionSignal.
+	signals []string
+	// reloads are the changed files handled by a reload rule.
+	reloads []string
+	// config is set when grove.toml changed.
+	config bool
+}
+
+// add merges the requirements of rule into the pending run.
+func (p *pendingRun) add(rule WatchRule) {
+	if rule.Command != "" && !slices.Contains(p.commands, rule.Command) {
+		p.commands = append(p.commands, rule.Command)
+	}
+	if rule.Action == ActionSignal && !slices.Contains(p.signals, rule.Signal) {
+		p.signals = append(p.signals, rule.Signal)
+	}
+	if actionRank[rule.Action] > actionRank[p.action] {
+		p.action = rule.Action
+	}
+}
+
+// takePending clears the pending changes and returns what they require.
+// Files whose content is unchanged are dropped: the check runs once the
+// debounce window has closed, when the writes have settled, so a file that
+// was truncated and rewritten with the same content is not seen half-way.
+func (w *Watcher) takePending() pendingRun {
+	w.mu.Lock()
+	changed := w.pending
+	w.pending = map[string]WatchRule{}
+	w.mu.Unlock()
+
+	// Rules are applied in declaration order so their commands run in the
+	// order grove.toml lists them, followed by the implicit ones: a rebuild
+	// for matched extensions and a restart for env files.
+	var p pendingRun
+	if w.takeForceBuild() {
+		p.add(WatchRule{Action: ActionBuild})
+	}
+
+	hit := map[string]WatchRule{}
+	for path, rule := range changed {
+		if w.contentChanged(path) {
+			hit[rule.Pattern] = rule
+			if rule.Action == ActionReload {
+				p.reloads = append(p.reloads, path)
+			}
+			if isConfigFile(path) {
+				p.config = true
+			}
+		}
+	}
+
+	for _, rule := range w.cfg.Watch {
+		if _, ok := hit[rule.Pattern]; ok {
+			p.add(rule)
+			delete(hit, rule.Pattern)
+		}
+	}
+	for _, rule := range hit {
+		p.add(rule)
+	}
+	return p
+}
+
+// scheduleRebuild records that path changed and arms (or resets) the
+// debounce timer.  When the timer fires it sends a single token on
+// rebuildCh, which the rebuild worker drains.
+func (w *Watcher) scheduleRebuild(path string, rule WatchRule) {
 	w.mu.Lock()
 	defer w.mu.Unlock()
 
+	w.pending[path] = rule
+
 	if w.debounce != nil {
 		w.debounce.Stop()
 	}
@@ -222,6 +520,13 @@ func (w *Watcher) scheduleRebuild() {
 	delay := time.Duration(w.cfg.DebounceMs) * time.Millisecond
 
 	w.debounce = time.AfterFunc(delay, func() {
+		// A change that needs a rebuild makes the builds in flight obsolete.
+		w.mu.Lock()
+		if w.cancelBuild != nil && w.pendingBuild() {
+			w.cancelBuild()
+		}
+		w.mu.Unlock()
+
 		// Non-blocking send: if a rebuild is already queued the worker will
 		// pick it up; we don't need to queue another.
 		select {
@@ -231,22 +536,223 @@ func (w *Watcher) scheduleRebuild() {
 	})
 }
 
+// pendingBuild reports whether a pending change is handled by a rebuild and
+// left the file's content different from the last one seen. w.mu must be
+// held.
+func (w *Watcher) pendingBuild() bool {
+	for path, rule := range w.pending {
+		if rule.Action == ActionBuild && w.contentDiffers(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// absorbHookWrites drops the pending changes made while the pre_build hooks
+// ran, recording their content: the build about to start compiles them, so
+// they must neither cancel it nor queue another cycle. Rules with a command
+// are kept so the command still runs.
+func (w *Watcher) absorbHookWrites() {
+	// Give the event loop time to see the hooks' last writes.
+	time.Sleep(time.Duration(w.cfg.DebounceMs) * time.Millisecond)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for path, rule := range w.pending {
+		if rule.Action == ActionBuild && rule.Command == "" {
+			w.contentChanged(path)
+			delete(w.pending, path)
+		}
+	}
+}
+
+// takeForceBuild reports and clears whether the next run must rebuild.
+func (w *Watcher) takeForceBuild() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	force := w.forceBuild
+	w.forceBuild = false
+	return force
+}
+
 // ── Build + restart ───────────────────────────────────────────────────────────
 
-// runRebuild compiles the project and, on success, restarts the binary.
-// Build errors are printed but do not stop the watcher.
-func (w *Watcher) runRebuild() {
-	// Wait for the previous process to fully drain its output (including any
-	// panic dump) before resetting state and printing the RE-BUILDING banner.
-	// This prevents the banner from interleaving with the crash output of a
-	// process that panicked right after the last restart.
-	if w.proc != nil {
-		w.proc.WaitDone()
+// runPending runs the commands of p in order and then performs its action.
+// A failing command cancels the action.
+func (w *Watcher) runPending(p pendingRun) {
+	if p.config {
+		w.reloadLogView()
+	}
+
+	for _, line := range p.commands {
+		if _, err := w.runCommand(line); err != nil {
+			return
+		}
+	}
+
+	switch p.action {
+	case ActionBuild:
+		w.runRebuild()
+	case ActionRestart:
+		w.runRestart()
+	case ActionSignal:
+		w.runSignal(p.signals)
+	case ActionReload:
+		w.runReload(p.reloads)
+	}
+}
+
+// reloadLogView applies the log settings edited in grove.toml to the apps'
+// output, so they can be tuned without a restart. Only the keys whose value
+// changed in the file are applied: the others keep what --log-* flags or the
+// l key set. The other settings are read once.
+func (w *Watcher) reloadLogView() {
+	cfg, err := LoadConfig()
+	if err != nil {
+		fmt.Println()
+		logDev(ansiYellow + "⚠  " + err.Error() + ansiReset)
+		return
+	}
+	next, prev := logSettingsOf(cfg), w.logFile
+	w.logFile = next
+
+	cur := w.view.settings()
+	changed := false
+	if next.level != prev.level {
+		cur.level, changed = next.level, true
+	}
+	if !slices.Equal(next.fields, prev.fields) {
+		cur.fields, changed = next.fields, true
+	}
+	if !slices.Equal(next.hide, prev.hide) {
+		cur.hide, changed = next.hide, true
+	}
+	if !slices.Equal(next.formats, prev.formats) {
+		w.view.useFormats(next.formats)
+		changed = true
+	}
+	if !changed {
+		return
+	}
+	w.view.apply(cur.level, cur.fields, cur.hide)
+
+	desc := w.view.String()
+	if desc == "" {
+		desc = "everything"
+	}
+	fmt.Println()
+	logDev(badge(ansiBgBlue, "LOG VIEW") + "  " + ansiGray + desc + ansiReset)
+}
+
+// runCommand runs a hook or [[dev.watch]] command in cfg.Root, its output
+// formatted like compiler output. The raw output is returned so a failure can
+// be shown by the dev proxy.
+func (w *Watcher) runCommand(line string) (string, error) {
+	fmt.Println()
+	logDev(badge(ansiBgBlue, "RUNNING") + "  " + ansiGray + line + ansiReset)
+	fmt.Println()
+
+	parts := strings.Fields(line)
+	if len(parts) == 0 {
+		return "", nil
+	}
+	cmd := exec.Command(parts[0], parts[1:]...)
+	cmd.Dir = w.cfg.Root
+	var raw bytes.Buffer
+	out := io.MultiWriter(newBuildOutputWriter(os.Stderr), &raw)
+	cmd.Stdout = out
+	cmd.Stderr = out
+
+	if err := cmd.Run(); err != nil {
+		fmt.Println()
+		logDev(
+			badge(ansiBgRed, "COMMAND FAILED") + "  " + ansiRed + err.Error() + ansiReset,
+		)
+		fmt.Println()
+		return raw.String(), err
+	}
+	return raw.String(), nil
+}
+
+// runRestart restarts every app from its existing binary, without building.
+func (w *Watcher) runRestart() {
+	w.proxy.Hold()
+	for _, a := range w.apps {
+		a.proc.WaitDone()
+		a.out.resetSession()
+	}
+
+	fmt.Println()
+	logDev(badge(ansiBgBlue, "RESTARTING"))
+	fmt.Println()
+
+	var wg sync.WaitGroup
+	for _, a := range w.apps {
+		wg.Add(1)
+		go func(a *appRunner) {
+			defer wg.Done()
+			w.restartApp(a, "restart only")
+		}(a)
 	}
+	wg.Wait()
+}
+
+// runSignal sends each named signal to every running app.
+func (w *Watcher) runSignal(signals []string) {
+	for _, name := range signals {
+		for _, a := range w.apps {
+			if err := a.proc.Signal(signalNames[name]); err != nil {
+				a.log(
+					badge(ansiBgYellow, "SIGNAL") + "  " + ansiYellow +
+						"cannot send " + name + ": " + err.Error() + ansiReset,
+				)
+				continue
+			}
+			a.log(badge(ansiBgBlue, "SIGNAL") + "  " + ansiGray + "sent " + name + ansiReset)
+		}
+	}
+}
 
-	// Reset per-session state (hints, panic buffer) so every rebuild starts
-	// clean and hints are shown again if the error persists.
-	appOut.resetSession()
+// runReload reloads the pages open through the dev proxy, or only swaps their
+// stylesheets when every changed file is one.
+func (w *Watcher) runReload(paths []string) {
+	var names []string
+	for _, p := range paths {
+		if filepath.Ext(p) != ".css" {
+			names = nil
+			break
+		}
+		names = append(names, filepath.Base(p))
+	}
+	slices.Sort(names)
+
+	note := "pages reloaded"
+	if len(names) > 0 {
+		w.proxy.ReloadCSS(names)
+		note = strings.Join(names, ", ") + " swapped"
+	} else {
+		w.proxy.Reload()
+	}
+	logDev(badge(ansiBgBlue, "LIVE RELOAD") + "  " + ansiGray + note + ansiReset)
+}
+
+// runRebuild compiles every app in parallel and restarts each one whose
+// build succeeded. Build errors are printed but do not stop the watcher.
+func (w *Watcher) runRebuild() {
+	// Requests reaching the dev proxy wait for the outcome of this cycle.
+	w.proxy.Hold()
+
+	// Wait for the previous processes to fully drain their output (including
+	// any panic dump) before resetting state and printing the RE-BUILDING
+	// banner. This prevents the banner from interleaving with the crash output
+	// of a process that panicked right after the last restart.
+	for _, a := range w.apps {
+		a.proc.WaitDone()
+
+		// Reset per-session state (hints, panic buffer) so every rebuild
+		// starts clean and hints are shown again if the error persists.
+		a.out.resetSession()
+	}
 
 	fmt.Println()
 	logDev(
@@ -254,79 +760,222 @@ func (w *Watcher) runRebuild() {
 	)
 	fmt.Println()
 
-	start := time.Now()
+	// ── pre_build ─────────────────────────────────────────────────────────────
+	for _, line := range w.cfg.PreBuild {
+		if out, err := w.runCommand(line); err != nil {
+			w.proxy.Fail("pre_build failed: "+line, out)
+			return
+		}
+	}
+	if len(w.cfg.PreBuild) > 0 {
+		w.absorbHookWrites()
+	}
 
-	if err := w.build(); err != nil {
-		fmt.Println()
-		logDev(badge(ansiBgRed, "BUILD FAILED"))
+	// ── build every app ───────────────────────────────────────────────────────
+	// A change arriving while the apps compile cancels ctx, killing the
+	// builds; the rebuild worker then starts a new cycle right away.
+	ctx, cancel := context.WithCancel(context.Background())
+	w.mu.Lock()
+	w.cancelBuild = cancel
+	w.mu.Unlock()
+
+	// elapsed[i] is the build time of w.apps[i], or zero when it failed.
+	elapsed := make([]time.Duration, len(w.apps))
+	output := make([]string, len(w.apps))
+	var wg sync.WaitGroup
+	for i, a := range w.apps {
+		wg.Add(1)
+		go func(i int, a *appRunner) {
+			defer wg.Done()
+			elapsed[i], output[i] = w.buildApp(ctx, a)
+		}(i, a)
+	}
+	wg.Wait()
+
+	w.mu.Lock()
+	w.cancelBuild = nil
+	cancelled := ctx.Err() != nil
+	if cancelled {
+		w.forceBuild = true
+	}
+	w.mu.Unlock()
+	cancel()
+
+	if cancelled {
+		// The proxy keeps holding requests for the next cycle.
 		fmt.Println()
+		logDev(
+			badge(ansiBgYellow, "BUILD CANCELLED") + "  " + ansiGray +
+				"newer changes — starting over" + ansiReset,
+		)
 		return
 	}
 
-	elapsed := time.Since(start)
+	// The proxy fronts the first app.
+	if elapsed[0] == 0 {
+		w.proxy.Fail("Build failed", output[0])
+	}
+
+	built := 0
+	for _, d := range elapsed {
+		if d > 0 {
+			built++
+		}
+	}
+	if built == 0 {
+		return
+	}
+
+	// ── post_build ────────────────────────────────────────────────────────────
+	for _, line := range w.cfg.PostBuild {
+		if out, err := w.runCommand(line); err != nil {
+			w.proxy.Fail("post_build failed: "+line, out)
+			return
+		}
+	}
+
+	// ── restart the apps that built ───────────────────────────────────────────
+	for i, a := range w.apps {
+		if elapsed[i] == 0 {
+			continue
+		}
+		wg.Add(1)
+		go func(a *appRunner, d time.Duration) {
+			defer wg.Done()
+			w.restartApp(a, fmtElapsed(d))
+		}(a, elapsed[i])
+	}
+	wg.Wait()
+}
+
+// buildApp compiles a single app and returns how long it took, or zero and
+// the compiler output when the build failed. A build cancelled through ctx
+// fails silently.
+func (w *Watcher) buildApp(ctx context.Context, a *appRunner) (time.Duration, string) {
+	start := time.Now()
+
+	if out, err := w.build(ctx, a); err != nil {
+		if ctx.Err() != nil {
+			return 0, ""
+		}
+		fmt.Fprintln(a.stdout)
+		a.log(badge(ansiBgRed, "BUILD FAILED"))
+		fmt.Fprintln(a.stdout)
+		return 0, out
+	}
+
+	return max(time.Since(start), time.Nanosecond), ""
+}
+
+// restartApp restarts a single app and reports whether it came up. note is
+// shown next to the APP RESTARTED badge.
+func (w *Watcher) restartApp(a *appRunner, note string) {
+	start := time.Now()
+	// Only the first app sits behind the dev proxy.
+	proxy := w.proxy
+	if a != w.apps[0] {
+		proxy = nil
+	}
 
-	result, err := w.proc.Restart(w.cfg.Bin)
+	result, err := a.proc.Restart(a.app.Bin, w.environ())
 	if err != nil {
-		fmt.Println()
-		logDev(
+		proxy.Fail("Failed to start binary", err.Error())
+		fmt.Fprintln(a.stdout)
+		a.log(
 			badge(
 				ansiBgRed,
 				"ERRO",
 			) + "  " + ansiRed + ansiBold + "❌ Failed to start binary: " + ansiReset + ansiRed + err.Error() + ansiReset,
 		)
-		fmt.Println()
+		fmt.Fprintln(a.stdout)
 		return
 	}
 
-	// Wait for either the stabilisation window (process is healthy) or an
-	// immediate crash (startup panic). In the crash case, DoneCh will be
+	// Wait for the process to be up (health check passed, or stabilisation
+	// window survived), to crash first (startup panic, failed connection) or
+	// for the health check to time out. In the crash case, DoneCh will be
 	// closed shortly after — we wait on it so the full panic output is
 	// printed before we return and the caller can schedule the next rebuild.
 	select {
 	case <-result.ReadyCh:
-		// Process survived the stabilisation window — it looks healthy.
-		fmt.Println()
-		logDev(
+		proxy.Open()
+		if a.proc.ready.checked() {
+			note += " · ready in " + fmtElapsed(time.Since(start))
+		}
+		fmt.Fprintln(a.stdout)
+		a.log(
 			badge(ansiBgGreen, "APP RESTARTED") +
-				"  " + ansiGray + "(" + fmtElapsed(elapsed) + ")" + ansiReset,
+				"  " + ansiGray + "(" + note + ")" + ansiReset,
 		)
-		fmt.Println()
+		fmt.Fprintln(a.stdout)
 
 	case <-result.CrashCh:
-		// Process exited immediately — wait for all pipe output (panic dump,
-		// error messages) to be fully flushed before returning.
+		// Process exited before it was up — wait for all pipe output (panic
+		// dump, error messages) to be fully flushed before returning.
 		<-result.DoneCh
-		fmt.Println()
-		logDev(
+		reason := "process exited immediately after start"
+		if a.proc.ready.checked() {
+			reason = "process exited after " + fmtElapsed(time.Since(start)) +
+				", before " + a.proc.ready.String() + " answered"
+		}
+		proxy.Fail("App crashed", reason+". See the grove dev output.")
+		fmt.Fprintln(a.stdout)
+		a.log(
 			badge(ansiBgRed, "APP CRASHED") +
-				"  " + ansiGray + "process exited immediately after start" + ansiReset,
+				"  " + ansiGray + reason + ansiReset,
 		)
-		fmt.Println()
+		fmt.Fprintln(a.stdout)
+
+	case <-result.TimeoutCh:
+		// Let requests through: the app is running and may answer them.
+		proxy.Open()
+		fmt.Fprintln(a.stdout)
+		a.log(
+			badge(ansiBgYellow, "NOT READY") +
+				"  " + ansiGray + a.proc.ready.String() + " did not answer within " +
+				fmtElapsed(w.cfg.HealthTimeout) + " — the app is still running" + ansiReset,
+		)
+		fmt.Fprintln(a.stdout)
 	}
 }
 
-// build runs cfg.BuildCmd in cfg.Root, piping stderr to the terminal.
-func (w *Watcher) build() error {
-	parts := strings.Fields(w.cfg.BuildCmd)
+// build runs the app's build command in cfg.Root, piping compiler output to
+// the terminal. The raw output is returned as well. Cancelling ctx interrupts
+// the command, which lets go build stop its compiler processes.
+func (w *Watcher) build(ctx context.Context, a *appRunner) (string, error) {
+	parts := strings.Fields(a.app.BuildCmd)
 	if len(parts) == 0 {
-		return fmt.Errorf("build_cmd is empty")
+		return "", fmt.Errorf("build_cmd is empty")
 	}
 
-	cmd := exec.Command(parts[0], parts[1:]...)
+	cmd := exec.CommandContext(ctx, parts[0], parts[1:]...)
+	cmd.Cancel = func() error {
+		// Windows cannot deliver os.Interrupt to a process: kill it instead
+		// of waiting out WaitDelay.
+		if runtime.GOOS == "windows" {
+			return cmd.Process.Kill()
+		}
+		return cmd.Process.Signal(os.Interrupt)
+	}
+	cmd.WaitDelay = 5 * time.Second
 	cmd.Dir = w.cfg.Root
-	// Pipe compiler output through the build writer which colourises each line.
-	bw := newBuildOutputWriter(os.Stderr)
-	cmd.Stdout = bw
-	cmd.Stderr = bw
-
-	return cmd.Run()
+	// Pipe compiler output through the build writer which colourises each
+	// line, keeping an uncoloured copy for the dev proxy's error page.
+	var raw bytes.Buffer
+	out := io.MultiWriter(newBuildOutputWriter(a.stderr), &raw)
+	cmd.Stdout = out
+	cmd.Stderr = out
+
+	err := cmd.Run()
+	return raw.String(), err
 }
 
 // ── Recursive watch ───────────────────────────────────────────────────────────
 
 // addRecursive walks dir and registers every non-excluded subdirectory with
-// the fsnotify watcher.  Errors for individual directories are silently
-// skipped so that a missing watch_dir entry does not abort startup.
+// the fsnotify watcher, recording the content of the files it handles.
+// Errors for individual directories are silently skipped so that a missing
+// watch_dir entry does not abort startup.
 func (w *Watcher) addRecursive(dir string) error {
 	return filepath.WalkDir(
 		dir,
@@ -336,6 +985,11 @@ func (w *Watcher) addRecursive(dir string) error {
 				return nil
 			}
 			if !d.IsDir() {
+				// Record the current content of handled files so that
+				// rewriting one unchanged is recognised from the start.
+				if _, ok := w.ruleFor(path); ok && !w.isExcluded(path) {
+					w.contentChanged(path)
+				}
 				return nil
 			}
 			if w.isExcluded(path) {
@@ -354,10 +1008,16 @@ func (w *Watcher) addRecursive(dir string) error {
 
 // ── UI helpers ────────────────────────────────────────────────────────────────
 
-func (w *Watcher) printHeader() {
+// printHeader prints the settings grove dev runs with. keys reports whether
+// the keyboard controls are enabled.
+func (w *Watcher) printHeader(keys bool) {
 	sep := "  " + ansiDim + strings.Repeat("─", 54) + ansiReset
 
 	exts := strings.Join(w.cfg.Extensions, " ")
+	stopHint := "Ctrl+C to stop"
+	if keys {
+		stopHint = "press ? for keys, q to quit"
+	}
 	dirs := strings.Join(w.cfg.WatchDirs, ", ")
 
 	fmt.Println()
@@ -367,7 +1027,7 @@ func (w *Watcher) printHeader() {
 		badge(
 			ansiBgGrove,
 			"GROVE DEV",
-		) + "  " + ansiGray + "watching for changes — Ctrl+C to stop" + ansiReset,
+		) + "  " + ansiGray + "watching for changes — " + stopHint + ansiReset,
 	)
 	fmt.Println()
 	logDev(
@@ -382,9 +1042,57 @@ func (w *Watcher) printHeader() {
 			w.cfg.DebounceMs,
 		) + ansiReset,
 	)
-	logDev(
-		ansiGray + "  binary      " + ansiReset + ansiBold + w.cfg.Bin + ansiReset,
-	)
+	if w.cfg.Profile != "" {
+		logDev(
+			ansiGray + "  profile     " + ansiReset + ansiBold + w.cfg.Profile + ansiReset,
+		)
+	}
+	if len(w.cfg.EnvFile) > 0 {
+		logDev(
+			ansiGray + "  env files   " + ansiReset + ansiBold + strings.Join(w.cfg.EnvFile, ", ") + ansiReset,
+		)
+	}
+	for _, rule := range w.cfg.Watch {
+		action := string(rule.Action)
+		if rule.Action == ActionSignal {
+			action = rule.Signal
+		}
+		if rule.Command != "" {
+			action = rule.Command + " → " + action
+		}
+		logDev(
+			ansiGray + "  watch       " + ansiReset + ansiBold + rule.Pattern + ansiReset +
+				ansiGray + "  " + action + ansiReset,
+		)
+	}
+	if w.proxy != nil {
+		logDev(
+			ansiGray + "  proxy       " + ansiReset + ansiBold + w.proxy.String() + ansiReset,
+		)
+	}
+	if view := w.view.String(); view != "" {
+		logDev(
+			ansiGray + "  logs        " + ansiReset + ansiBold + view + ansiReset,
+		)
+	}
+	if len(w.apps) == 1 {
+		logDev(
+			ansiGray + "  binary      " + ansiReset + ansiBold + w.apps[0].app.Bin + ansiReset,
+		)
+		if url := w.apps[0].app.HealthURL; url != "" {
+			logDev(
+				ansiGray + "  health      " + ansiReset + ansiBold + url + ansiReset,
+			)
+		}
+	} else {
+		names := make([]string, 0, len(w.apps))
+		for _, a := range w.apps {
+			names = append(names, a.app.Name)
+		}
+		logDev(
+			ansiGray + "  apps        " + ansiReset + ansiBold + strings.Join(names, ", ") + ansiReset,
+		)
+	}
 	fmt.Println()
 	fmt.Println(sep)
 	fmt.Println()
@@ -408,11 +1116,11 @@ func fmtElapsed(d time.Duration) string {
 // This matches the visual language of gest and makes it easy to scan which
 // package failed and which specific symbols are undefined.
 type buildOutputWriter struct {
-	w   *os.File
+	w   io.Writer
 	buf []byte
 }
 
-func newBuildOutputWriter(w *os.File) *buildOutputWriter {
+func newBuildOutputWriter(w io.Writer) *buildOutputWriter {
 	return &buildOutputWriter{w: w}
 }
 
@@ -448,6 +1156,48 @@ func (bw *buildOutputWriter) writeLine(line string) {
 	fmt.Fprintf(bw.w, "  %s× %s%s\n", ansiRed, line, ansiReset)
 }
 
+// ── prefixWriter ──────────────────────────────────────────────────────────────
+
+// termMu serialises writes of all prefixWriters so lines of apps running in
+// parallel never interleave mid-line.
+var termMu sync.Mutex
+
+// prefixWriter prepends prefix to every non-empty line written to w. Blank
+// lines are passed through untouched so the output keeps its spacing.
+type prefixWriter struct {
+	w       io.Writer
+	prefix  string
+	atStart bool
+}
+
+func (pw *prefixWriter) Write(p []byte) (n int, err error) {
+	termMu.Lock()
+	defer termMu.Unlock()
+
+	if pw.prefix == "" {
+		return pw.w.Write(p)
+	}
+
+	out := make([]byte, 0, len(p)+len(pw.prefix))
+	for rest := p; len(rest) > 0; {
+		line := rest
+		if nl := bytes.IndexByte(rest, '\n'); nl >= 0 {
+			line = rest[:nl+1]
+		}
+		if pw.atStart && line[0] != '\n' {
+			out = append(out, pw.prefix...)
+		}
+		out = append(out, line...)
+		pw.atStart = line[len(line)-1] == '\n'
+		rest = rest[len(line):]
+	}
+
+	if _, err := pw.w.Write(out); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
 // ── appOutputWriter ───────────────────────────────────────────────────────────
 
 // appOutputWriter processes the running application's stdout/stderr line by
@@ -459,15 +1209,18 @@ func (bw *buildOutputWriter) writeLine(line string) {
 //     in a red block with a clear PANIC badge.
 //   - All other lines are indented and passed through as-is.
 type appOutputWriter struct {
-	w        *os.File
+	w        io.Writer
+	view     *logView
+	app      string       // name recorded with each line
+	recorder *logRecorder // captures the raw lines; nil records nothing
 	buf      []byte
 	inPanic  bool
 	panicBuf []string
 	hintSeen map[string]bool
 }
 
-func newAppOutputWriter(w *os.File) *appOutputWriter {
-	return &appOutputWriter{w: w, hintSeen: map[string]bool{}}
+func newAppOutputWriter(w io.Writer, view *logView) *appOutputWriter {
+	return &appOutputWriter{w: w, view: view, hintSeen: map[string]bool{}}
 }
 
 // resetSession clears per-run state so hints are shown again on every rebuild.
@@ -510,6 +1263,9 @@ func (aw *appOutputWriter) Write(p []byte) (n int, err error) {
 
 func (aw *appOutputWriter) writeLine(line string) {
 	trimmed := strings.TrimSpace(line)
+	if trimmed != "" {
+		aw.recorder.Record(aw.app, line)
+	}
 
 	// ── blank line ────────────────────────────────────────────────────────────
 	if trimmed == "" {
@@ -549,21 +1305,14 @@ func (aw *appOutputWriter) writeLine(line string) {
 		aw.flushPanic()
 	}
 
-	// ── JSON structured log line ──────────────────────────────────────────────
-	if len(trimmed) > 0 && trimmed[0] == '{' {
-		if rendered, allText, ok := renderJSONLog(trimmed); ok {
-			fmt.Fprintln(aw.w, rendered)
-			aw.detectHints(allText)
+	// ── Structured log line ───────────────────────────────────────────────────
+	// JSON, logfmt, logrus, zap console, Apache and standard log lines, and
+	// the custom formats of grove.toml; see builtinLogParsers.
+	if entry, ok := aw.view.parse(trimmed); ok {
+		if !aw.view.shows(entry.Level) {
 			return
 		}
-	}
-
-	// ── slog text format (key=value) ──────────────────────────────────────────
-	// Matches the default output of log/slog's TextHandler:
-	//   time=2006-01-02T15:04:05.000Z level=INFO msg="hello" key=val
-	// Also matches logfmt-style lines that lack a time= prefix as long as
-	// they contain at least level= and msg= (or message=).
-	if rendered, allText, ok := renderSlogText(trimmed); ok {
+		rendered, allText := renderLogEntry(entry, aw.view)
 		fmt.Fprintln(aw.w, rendered)
 		aw.detectHints(allText)
 		return
@@ -646,84 +1395,43 @@ func (aw *appOutputWriter) flushPanic() {
 	aw.panicBuf = nil
 }
 
-// renderSlogText attempts to parse a log/slog TextHandler (or logfmt) line of
-// the form:
+// renderLogEntry renders e as a human-readable coloured line, with the extra
+// fields view shows:
 //
-//	time=2006-01-02T15:04:05.000Z level=INFO msg="hello world" key=val key2="v 2"
+//	08:38:28  ERR  Failed to boot application  error=failed to connect
 //
-// It returns (rendered, allText, true) on success, ("", "", false) if the line
-// does not look like a slog text entry (must have at least level= and msg= /
-// message= keys).
-func renderSlogText(line string) (string, string, bool) {
-	kv := parseSlogKV(line)
-	if len(kv) == 0 {
-		return "", "", false
-	}
-
-	level := firstOf(kv, "level", "lvl", "severity")
-	msg := firstOf(kv, "msg", "message", "Message")
-	ts := firstOf(kv, "time", "ts", "timestamp", "Time")
-
-	// Require at least level + msg to treat this as a structured log line.
-	if level == "" || msg == "" {
-		return "", "", false
-	}
-
-	// ── Level badge ───────────────────────────────────────────────────────────
-	var levelBadge string
-	switch strings.ToUpper(level) {
-	case "DEBUG":
-		levelBadge = ansiGray + ansiBold + "DBG" + ansiReset
-	case "INFO":
-		levelBadge = ansiGreen + ansiBold + "INF" + ansiReset
-	case "WARN", "WARNING":
-		levelBadge = ansiYellow + ansiBold + "WRN" + ansiReset
-	case "ERROR", "ERR":
-		levelBadge = ansiRed + ansiBold + "ERR" + ansiReset
-	case "FATAL", "PANIC":
-		levelBadge = ansiBgRed + " " + level + " " + ansiReset
-	default:
-		levelBadge = ansiGray + ansiBold + "LOG" + ansiReset
-	}
-
+// It also returns the lowercased text of the message and every field, in
+// which detectHints looks for known errors.
+func renderLogEntry(e logEntry, view *logView) (string, string) {
 	// ── Timestamp — keep only HH:MM:SS ───────────────────────────────────────
 	timeStr := ""
-	if ts != "" {
-		if idx := strings.IndexByte(ts, 'T'); idx >= 0 && len(ts) > idx+9 {
-			timeStr = ansiDim + ansiGray + ts[idx+1:idx+9] + ansiReset + "  "
-		}
+	if hms := clockTime(e.Time); hms != "" {
+		timeStr = ansiDim + ansiGray + hms + ansiReset + "  "
 	}
 
-	// ── Message colour ────────────────────────────────────────────────────────
-	var msgColour string
-	switch strings.ToUpper(level) {
-	case "ERROR", "ERR", "FATAL", "PANIC":
-		msgColour = ansiRed
-	case "WARN", "WARNING":
-		msgColour = ansiYellow
-	}
-	msgPart := msg
-	if msgColour != "" {
-		msgPart = msgColour + msg + ansiReset
+	// ── Colour the message based on level ────────────────────────────────────
+	msgPart := e.Msg
+	switch levelRank(e.Level) {
+	case 3:
+		msgPart = ansiRed + e.Msg + ansiReset
+	case 2:
+		msgPart = ansiYellow + e.Msg + ansiReset
 	}
 
-	// ── Extra fields (skip standard ones already rendered) ────────────────────
-	skip := map[string]bool{
-		"level": true, "lvl": true, "severity": true,
-		"msg": true, "message": true, "Message": true,
-		"time": true, "ts": true, "timestamp": true, "Time": true,
+	// ── Extra fields ──────────────────────────────────────────────────────────
+	allText := strings.ToLower(e.Msg)
+	keys := make([]string, len(e.Fields))
+	values := make(map[string]string, len(e.Fields))
+	for i, f := range e.Fields {
+		allText += " " + strings.ToLower(f.Value)
+		keys[i] = f.Key
+		values[f.Key] = f.Value
 	}
 
-	// Build allText for hint detection while collecting extras.
-	allText := strings.ToLower(msg)
 	var extras []string
-	for _, k := range kvKeys(kv) {
-		v := kv[k]
-		allText += " " + strings.ToLower(v)
-		if skip[k] {
-			continue
-		}
-		val := v
+	for _, k := range view.project(keys) {
+		val := values[k]
+		// Truncate very long values.
 		if len(val) > 120 {
 			val = val[:117] + "…"
 		}
@@ -737,247 +1445,31 @@ func renderSlogText(line string) (string, string, bool) {
 
 	return fmt.Sprintf(
 		"  %s%s%s  %s%s",
-		timeStr, levelBadge, ansiReset, msgPart, extra,
-	), allText, true
-}
-
-// parseSlogKV parses a slog TextHandler / logfmt line into an ordered map.
-// Values may be bare tokens or double-quoted strings (with \" escaping).
-// The insertion order of keys is preserved via kvKeys.
-func parseSlogKV(line string) map[string]string {
-	out := make(map[string]string)
-	rest := line
-	for len(rest) > 0 {
-		rest = strings.TrimLeft(rest, " \t")
-		if rest == "" {
-			break
-		}
-
-		// Find the '=' that separates key from value.
-		eqIdx := strings.IndexByte(rest, '=')
-		if eqIdx <= 0 {
-			break
-		}
-		key := rest[:eqIdx]
-		rest = rest[eqIdx+1:]
-
-		// Keys must be plain identifiers (no spaces).
-		if strings.ContainsAny(key, " \t\"") {
-			break
-		}
-
-		var val string
-		if len(rest) > 0 && rest[0] == '"' {
-			// Quoted value — scan to the closing unescaped '"'.
-			i := 1
-			var buf strings.Builder
-			for i < len(rest) {
-				if rest[i] == '\\' && i+1 < len(rest) {
-					buf.WriteByte(rest[i+1])
-					i += 2
-					continue
-				}
-				if rest[i] == '"' {
-					i++
-					break
-				}
-				buf.WriteByte(rest[i])
-				i++
-			}
-			val = buf.String()
-			rest = rest[i:]
-		} else {
-			// Bare value — ends at the next space.
-			end := strings.IndexAny(rest, " \t")
-			if end < 0 {
-				val = rest
-				rest = ""
-			} else {
-				val = rest[:end]
-				rest = rest[end:]
-			}
-		}
-
-		if _, exists := out[key]; !exists {
-			out[key] = val
-		}
-	}
-	return out
+		timeStr, levelBadge(e.Level), ansiReset, msgPart, extra,
+	), allText
 }
 
-// kvKeys returns the keys of a slog KV map in a stable order: standard fields
-// first (time, level, msg), then the rest in the order they were encountered.
-// Because Go maps are unordered we reconstruct order from the original line.
-func kvKeys(kv map[string]string) []string {
-	priority := []string{
-		"time",
-		"ts",
-		"timestamp",
-		"Time",
-		"level",
-		"lvl",
-		"severity",
-		"msg",
-		"message",
-		"Message",
-	}
-	seen := make(map[string]bool)
-	var out []string
-	for _, k := range priority {
-		if _, ok := kv[k]; ok {
-			out = append(out, k)
-			seen[k] = true
-		}
-	}
-	for k := range kv {
-		if !seen[k] {
-			out = append(out, k)
-		}
-	}
-	return out
-}
-
-// firstOf returns the first non-empty value found for any of the given keys.
-func firstOf(m map[string]string, keys ...string) string {
-	for _, k := range keys {
-		if v, ok := m[k]; ok && v != "" {
-			return v
-		}
-	}
-	return ""
-}
-
-// renderJSONLog attempts to parse line as a structured log entry (slog/zap/
-// zerolog) and renders it as a human-readable coloured string.
-// Returns (rendered, true) on success, ("", false) if line is not valid JSON
-// or doesn't look like a log entry.
-//
-// For known error patterns (database connection refused, etc.) an actionable
-// hint is printed to os.Stdout immediately after the log line.
-func renderJSONLog(line string) (string, string, bool) {
-	var entry map[string]interface{}
-	if err := json.Unmarshal([]byte(line), &entry); err != nil {
-		return "", "", false
-	}
-
-	// ── Extract standard fields ───────────────────────────────────────────────
-	level := extractString(entry, "level", "lvl", "severity")
-	msg := extractString(entry, "msg", "message", "Message")
-	ts := extractString(entry, "time", "ts", "timestamp", "Time")
-
-	if msg == "" {
-		return "", "", false
-	}
-
-	// ── Detect known error patterns and queue a hint ──────────────────────────
-	// Collect all string values in the entry to search across msg + extra fields.
-	allText := strings.ToLower(msg)
-	for _, v := range entry {
-		if s, ok := v.(string); ok {
-			allText += " " + strings.ToLower(s)
-		}
-	}
-	// Build allText — the combined lowercased text of all fields — returned to
-	// the caller so it can call detectHints without needing to re-parse.
-
-	// ── Level badge ───────────────────────────────────────────────────────────
-	var levelBadge string
+// levelBadge returns the three-letter badge of a level, a red block for fatal
+// levels, or LOG when the level is missing or unknown.
+func levelBadge(level string) string {
 	switch strings.ToUpper(level) {
-	case "DEBUG":
-		levelBadge = ansiGray + ansiBold + "DBG" + ansiReset
-	case "INFO":
-		levelBadge = ansiGreen + ansiBold + "INF" + ansiReset
-	case "WARN", "WARNING":
-		levelBadge = ansiYellow + ansiBold + "WRN" + ansiReset
-	case "ERROR", "ERR":
-		levelBadge = ansiRed + ansiBold + "ERR" + ansiReset
-	case "FATAL", "PANIC":
-		levelBadge = ansiBgRed + " " + level + " " + ansiReset
-	default:
-		levelBadge = ansiGray + ansiBold + "LOG" + ansiReset
+	case "FATAL", "FATA":
+		return ansiBgRed + " FATAL " + ansiReset
+	case "PANIC", "PANI", "DPANIC":
+		return ansiBgRed + " PANIC " + ansiReset
 	}
 
-	// ── Timestamp — keep only HH:MM:SS ───────────────────────────────────────
-	timeStr := ""
-	if ts != "" {
-		// ISO-8601: take only the time portion HH:MM:SS
-		if idx := strings.IndexByte(ts, 'T'); idx >= 0 && len(ts) > idx+9 {
-			timeStr = ansiDim + ansiGray + ts[idx+1:idx+9] + ansiReset + "  "
-		}
-	}
-
-	// ── Extra fields (skip standard ones already rendered) ───────────────────
-	skip := map[string]bool{
-		"level": true, "lvl": true, "severity": true,
-		"msg": true, "message": true, "Message": true,
-		"time": true, "ts": true, "timestamp": true, "Time": true,
-	}
-
-	var extras []string
-	for k, v := range entry {
-		if skip[k] {
-			continue
-		}
-		var valStr string
-		switch vv := v.(type) {
-		case string:
-			valStr = vv
-		default:
-			b, _ := json.Marshal(vv)
-			valStr = string(b)
-		}
-		// Truncate very long values.
-		if len(valStr) > 120 {
-			valStr = valStr[:117] + "…"
-		}
-		extras = append(
-			extras,
-			ansiGray+k+"="+ansiReset+ansiDim+valStr+ansiReset,
-		)
-	}
-
-	// ── Colour the message based on level ────────────────────────────────────
-	var msgColour string
-	switch strings.ToUpper(level) {
-	case "ERROR", "ERR", "FATAL", "PANIC":
-		msgColour = ansiRed
-	case "WARN", "WARNING":
-		msgColour = ansiYellow
-	default:
-		msgColour = ""
-	}
-
-	msgPart := msgColour + msg + ansiReset
-	if msgColour == "" {
-		msgPart = msg
-	}
-
-	extra := ""
-	if len(extras) > 0 {
-		extra = "  " + strings.Join(extras, "  ")
-	}
-
-	return fmt.Sprintf(
-		"  %s%s%s  %s%s",
-		timeStr,
-		levelBadge,
-		ansiReset,
-		msgPart,
-		extra,
-	), allText, true
-}
-
-// extractString returns the first non-empty string value found for any of the
-// given keys in the map.
-func extractString(m map[string]interface{}, keys ...string) string {
-	for _, k := range keys {
-		if v, ok := m[k]; ok {
-			if s, ok := v.(string); ok && s != "" {
-				return s
-			}
-		}
+	switch levelRank(level) {
+	case 0:
+		return ansiGray + ansiBold + "DBG" + ansiReset
+	case 1:
+		return ansiGreen + ansiBold + "INF" + ansiReset
+	case 2:
+		return ansiYellow + ansiBold + "WRN" + ansiReset
+	case 3:
+		return ansiRed + ansiBold + "ERR" + ansiReset
 	}
-	return ""
+	return ansiGray + ansiBold + "LOG" + ansiReset
 }
 
 // ── Known-error hint engine ───────────────────────────────────────────────────
@@ -1018,7 +1510,7 @@ func (aw *appOutputWriter) detectHints(text string) {
 }
 
 // printHint renders a styled actionable hint block to w.
-func printHint(w *os.File, title string, steps []string) {
+func printHint(w io.Writer, title string, steps []string) {
 	fmt.Fprintln(w)
 	fmt.Fprintf(w,
 		"  %s  %s%s%s\n",
@@ -35,8 +35,12 @@ func buildBanner() string {
 		"    grove " + colorGreen + "make:controller" + colorReset + "  <Name>   Scaffold a fuego controller\n" +
 		"    grove " + colorGreen + "make:dto" + colorReset + "         <Name>   Scaffold a DTO request/response file\n" +
 		"    grove " + colorGreen + "make:middleware" + colorReset + "  <Name>   Scaffold an HTTP middleware\n" +
-		"    grove " + colorGreen + "make:migration" + colorReset + "   <name>   Generate a migration via atlas migrate diff\n" +
-		"    grove " + colorGreen + "make:resource" + colorReset + "    <Name>   Scaffold model + controller + DTO at once\n"
+		"    grove " + colorGreen + "make:migration" + colorReset + "   <name>   Generate a migration via atlas migrate diff (or --offline)\n" +
+		"    grove " + colorGreen + "make:resource" + colorReset + "    <Name>   Scaffold model + controller + DTO at once\n" +
+		"    grove " + colorGreen + "make:seeder" + colorReset + "      <Name>   Scaffold a database seeder\n" +
+		"    grove " + colorRed + "destroy:<kind>" + colorReset + "   <Name>   Undo a generator (model, controller, dto, middleware, resource, test)\n" +
+		"    grove " + colorGreen + "stubs:publish" + colorReset + "             Copy generator stubs to .grove/stubs for editing\n" +
+		"    " + colorGray + "Add --dry-run to make:*, destroy:* and stubs:publish to preview files and diffs first" + colorReset + "\n"
 
 	update := "\n" +
 		"  " + colorBold + colorGray + "MAINTENANCE" + colorReset + "\n" +
@@ -46,7 +50,8 @@ func buildBanner() string {
 		"  " + colorBold + colorGray + "SERVER" + colorReset + "\n" +
 		"    grove " + colorBlue + "dev" + colorReset + "               Hot reload — watch, build & restart; formats JSON logs & hints on error\n" +
 		"    grove " + colorBlue + "dev:air" + colorReset + "           Start the development server using Air for hot-reload\n" +
-		"    grove " + colorBlue + "build" + colorReset + "             Compile the application to a binary\n"
+		"    grove " + colorBlue + "build" + colorReset + "             Compile the application to a binary\n" +
+		"    grove " + colorBlue + "logs" + colorReset + "              Search and replay the output captured by grove dev\n"
 
 	database := "\n" +
 		"  " + colorBold + colorGray + "DATABASE" + colorReset + "\n" +
@@ -54,11 +59,14 @@ func buildBanner() string {
 		"    grove " + colorBlue + "migrate:rollback" + colorReset + "        Rollback the last migration\n" +
 		"    grove " + colorBlue + "migrate:status" + colorReset + "          Show migration status\n" +
 		"    grove " + colorBlue + "migrate:fresh" + colorReset + "           Drop + re-apply all migrations\n" +
-		"    grove " + colorBlue + "migrate:hash" + colorReset + "            Rehash the migrations directory\n"
+		"    grove " + colorBlue + "migrate:hash" + colorReset + "            Rehash the migrations directory\n" +
+		"    grove " + colorBlue + "db:seed" + colorReset + "                 Run the database seeders\n" +
+		"    grove " + colorBlue + "schema:dump" + colorReset + "             Print the SQL schema of the GORM models\n"
 
 	testing := "\n" +
 		"  " + colorBold + colorGray + "TESTING" + colorReset + "\n" +
 		"    grove " + colorGreen + "make:test" + colorReset + "        <Name>   Scaffold a new gest v2 test file in internal/tests/\n" +
+		"    grove " + colorGreen + "make:factory" + colorReset + "     <Model>  Scaffold a model factory for tests\n" +
 		"    grove " + colorBlue + "test" + colorReset + "              Run all tests (gest CLI if installed, else go test -v)\n" +
 		"    grove " + colorBlue + "test -c" + colorReset + "           Run tests + display per-suite coverage report\n" +
 		"    grove " + colorBlue + "test -w" + colorReset + "           Watch mode — re-run tests on every save\n" +
@@ -83,6 +91,30 @@ func init() {
 			"  " + colorGray + "github.com/caiolandgraf/grove" + colorReset + "\n\n",
 	)
 
+	// ── --dry-run ─────────────────────────────────────────────────────────────
+	// Only the commands that write files honour --dry-run, so only they accept
+	// it: a database or build command must never promise a dry run.
+	for _, cmd := range []*cobra.Command{
+		makeModelCmd, makeControllerCmd, makeDtoCmd, makeRequestCmd,
+		makeMiddlewareCmd, makeMigrationCmd, makeResourceCmd, makeSeederCmd,
+		makeFactoryCmd, makeTestCmd,
+		destroyModelCmd, destroyControllerCmd, destroyDtoCmd,
+		destroyMiddlewareCmd, destroyResourceCmd, destroyTestCmd,
+		stubsPublishCmd, schemaDumpCmd, migrateHashCmd,
+	} {
+		cmd.Flags().BoolVar(
+			&dryRun,
+			"dry-run", false,
+			"Preview what would be created or changed without writing files",
+		)
+	}
+	rootCmd.PersistentPreRun = func(_ *cobra.Command, _ []string) {
+		if dryRun {
+			fmt.Println()
+			fmt.Println(info("Dry run — no files will be written."))
+		}
+	}
+
 	// ── Command groups (organises the "Available Commands" cobra help block) ──
 	rootCmd.AddGroup(
 		&cobra.Group{ID: "generators", Title: "Generators:"},
@@ -100,7 +132,16 @@ func init() {
 	makeMiddlewareCmd.GroupID = "generators"
 	makeMigrationCmd.GroupID = "generators"
 	makeResourceCmd.GroupID = "generators"
+	makeSeederCmd.GroupID = "generators"
 	makeTestCmd.GroupID = "testing"
+	makeFactoryCmd.GroupID = "testing"
+	stubsPublishCmd.GroupID = "generators"
+	destroyModelCmd.GroupID = "generators"
+	destroyControllerCmd.GroupID = "generators"
+	destroyDtoCmd.GroupID = "generators"
+	destroyMiddlewareCmd.GroupID = "generators"
+	destroyResourceCmd.GroupID = "generators"
+	destroyTestCmd.GroupID = "testing"
 
 	rootCmd.AddCommand(makeModelCmd)
 	rootCmd.AddCommand(makeControllerCmd)
@@ -109,7 +150,16 @@ func init() {
 	rootCmd.AddCommand(makeMiddlewareCmd)
 	rootCmd.AddCommand(makeMigrationCmd)
 	rootCmd.AddCommand(makeResourceCmd)
+	rootCmd.AddCommand(makeSeederCmd)
 	rootCmd.AddCommand(makeTestCmd)
+	rootCmd.AddCommand(makeFactoryCmd)
+	rootCmd.AddCommand(stubsPublishCmd)
+	rootCmd.AddCommand(destroyModelCmd)
+	rootCmd.AddCommand(destroyControllerCmd)
+	rootCmd.AddCommand(destroyDtoCmd)
+	rootCmd.AddCommand(destroyMiddlewareCmd)
+	rootCmd.AddCommand(destroyResourceCmd)
+	rootCmd.AddCommand(destroyTestCmd)
 
 	// ── Testing ───────────────────────────────────────────────────────────────
 	testCmd.GroupID = "testing"
@@ -120,10 +170,12 @@ func init() {
 	devCmd.GroupID = "server"
 	devAirCmd.GroupID = "server"
 	buildCmd.GroupID = "server"
+	logsCmd.GroupID = "server"
 
 	rootCmd.AddCommand(devCmd)
 	rootCmd.AddCommand(devAirCmd)
 	rootCmd.AddCommand(buildCmd)
+	rootCmd.AddCommand(logsCmd)
 
 	// ── Database ──────────────────────────────────────────────────────────────
 	migrateCmd.GroupID = "database"
@@ -131,12 +183,16 @@ func init() {
 	migrateStatusCmd.GroupID = "database"
 	migrateFreshCmd.GroupID = "database"
 	migrateHashCmd.GroupID = "database"
+	schemaDumpCmd.GroupID = "database"
+	dbSeedCmd.GroupID = "database"
 
 	rootCmd.AddCommand(migrateCmd)
 	rootCmd.AddCommand(migrateRollbackCmd)
 	rootCmd.AddCommand(migrateStatusCmd)
 	rootCmd.AddCommand(migrateFreshCmd)
 	rootCmd.AddCommand(migrateHashCmd)
+	rootCmd.AddCommand(schemaDumpCmd)
+	rootCmd.AddCommand(dbSeedCmd)
 
 	// ── Setup ─────────────────────────────────────────────────────────────────
 	setupCmd.GroupID = "setup"
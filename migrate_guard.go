@@ -0,0 +1,208 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"net/url"
+	"os"
+	"os/user"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ──────────────────────────────────────────────
+// Destructive command guard
+// ──────────────────────────────────────────────
+//
+// migrate:fresh and migrate:rollback go through guardDestructive before they
+// touch a database. Environments marked protected in grove.toml reject them
+// outright:
+//
+//	[database.env.production]
+//	protected = true
+//
+// production is protected unless grove.toml sets protected = false. Every
+// other environment needs the environment name typed back; --force skips
+// that only for the local environment. Each attempt is appended to
+// .grove/audit.log.
+
+// localEnv is the default --env, the developer's own database.
+const localEnv = "local"
+
+// auditLogPath records every destructive migrate command.
+var auditLogPath = filepath.Join(".grove", "audit.log")
+
+// guardDestructive decides whether command may run against env. It returns
+// false without an error when the user declines the confirmation.
+func guardDestructive(db databaseConfig, command, env string, force bool) (bool, error) {
+	target := targetDatabase(db, env)
+
+	if db.Protected(env) {
+		audit(command, env, target, "rejected")
+		return false, fmt.Errorf(
+			"environment %q is protected — %s is not allowed\n\n  Set %s under %s in grove.toml to allow it",
+			env, command,
+			colorCyan+"protected = false"+colorReset,
+			colorCyan+"[database.env."+env+"]"+colorReset,
+		)
+	}
+
+	fmt.Println()
+	fmt.Printf(
+		"  %s WARNING %s  %s is a %sdestructive%s operation.\n",
+		colorBgYellow, colorReset, bold(command), colorRed, colorReset,
+	)
+	fmt.Printf("  Environment: %s\n", bold(env))
+	fmt.Printf("  Database:    %s\n", bold(target))
+	fmt.Println()
+
+	if force && env == localEnv {
+		audit(command, env, target, "forced")
+		return true, nil
+	}
+	if force {
+		fmt.Println(gray("  --force only skips this question for the " + localEnv + " environment."))
+		fmt.Println()
+	}
+
+	fmt.Printf("  Type %s to continue: ", bold(env))
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	if strings.TrimSpace(answer) != env {
+		audit(command, env, target, "aborted")
+		fmt.Println()
+		fmt.Println(warn("Aborted."))
+		fmt.Println()
+		return false, nil
+	}
+
+	audit(command, env, target, "confirmed")
+	return true, nil
+}
+
+// audit appends one line to .grove/audit.log. A failure to write it is
+// reported but does not block the command.
+func audit(command, env, target, outcome string) {
+	who := "unknown"
+	if u, err := user.Current(); err == nil {
+		who = u.Username
+	}
+
+	line := fmt.Sprintf(
+		"%s user=%s command=%s env=%s database=%s outcome=%s\n",
+		time.Now().UTC().Format(time.RFC3339), who, command, env, target, outcome,
+	)
+
+	if err := ensureDir(filepath.Dir(auditLogPath)); err == nil {
+		f, err := os.OpenFile(auditLogPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+		if err == nil {
+			_, err = f.WriteString(line)
+			f.Close()
+		}
+		if err == nil {
+			return
+		}
+	}
+	fmt.Println(warn("Could not write " + auditLogPath))
+}
+
+// ──────────────────────────────────────────────
+// Target database
+// ──────────────────────────────────────────────
+
+// targetDatabase describes the database env points at, with the password
+// masked.
+func targetDatabase(db databaseConfig, env string) string {
+	raw := rawDatabaseURL(db, env)
+	if raw == "" && !db.Native() {
+		// Show an unresolved atlas.hcl expression as written.
+		raw, _ = atlasEnvURL(env)
+	}
+	if raw == "" {
+		return "unknown"
+	}
+	return redactURL(raw)
+}
+
+// rawDatabaseURL returns the connection URL of env, or "" when it cannot be
+// determined. The native engine reads it from grove.toml; with Atlas it comes
+// from the env block of atlas.hcl.
+func rawDatabaseURL(db databaseConfig, env string) string {
+	if db.Native() {
+		url, _ := db.EnvURL(env)
+		return url
+	}
+	url, resolved := atlasEnvURL(env)
+	if !resolved {
+		return ""
+	}
+	return url
+}
+
+var (
+	atlasURLPattern    = regexp.MustCompile(`(?m)^\s*url\s*=\s*(.+?)\s*$`)
+	atlasGetenvPattern = regexp.MustCompile(`^getenv\(\s*"([^"]+)"\s*\)$`)
+)
+
+// atlasEnvURL returns the url of `env "<name>" { … }` in atlas.hcl. String
+// literals and getenv("…") calls are resolved; other expressions are returned
+// as written with resolved set to false.
+func atlasEnvURL(env string) (url string, resolved bool) {
+	raw, err := os.ReadFile("atlas.hcl")
+	if err != nil {
+		return "", false
+	}
+	src := string(raw)
+
+	start := strings.Index(src, `env "`+env+`"`)
+	if start < 0 {
+		return "", false
+	}
+	block := src[start:]
+	open := strings.Index(block, "{")
+	if open < 0 {
+		return "", false
+	}
+
+	// Cut the block at its matching brace so nested blocks are included but
+	// the next env is not.
+	end, depth := len(block), 0
+scan:
+	for i := open; i < len(block); i++ {
+		switch block[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				end = i
+				break scan
+			}
+		}
+	}
+	block = block[:end]
+
+	m := atlasURLPattern.FindStringSubmatch(block)
+	if m == nil {
+		return "", false
+	}
+	expr := m[1]
+	if g := atlasGetenvPattern.FindStringSubmatch(expr); g != nil {
+		return os.Getenv(g[1]), true
+	}
+	if strings.HasPrefix(expr, `"`) && strings.HasSuffix(expr, `"`) && len(expr) >= 2 {
+		return strings.Trim(expr, `"`), true
+	}
+	return expr, false
+}
+
+var dsnPasswordPattern = regexp.MustCompile(`:[^:@/]*@`)
+
+// redactURL masks the password of a connection URL or DSN.
+func redactURL(raw string) string {
+	if u, err := url.Parse(raw); err == nil && u.Scheme != "" && u.Opaque == "" {
+		return u.Redacted()
+	}
+	return dsnPasswordPattern.ReplaceAllString(raw, ":xxxxx@")
+}
@@ -0,0 +1,308 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ──────────────────────────────────────────────
+// Machine-readable migration status
+// ──────────────────────────────────────────────
+//
+// "grove migrate:status --json" prints a statusReport instead of the
+// colourised text. The report is built the same way for both engines: the
+// applied revisions come from the database (read directly by the native
+// engine, or via "atlas migrate status --format" for Atlas) and are compared
+// with the migrations directory on disk.
+
+// statusReport is the JSON document printed by migrate:status --json.
+type statusReport struct {
+	Engine string `json:"engine"`
+	Env    string `json:"env"`
+
+	// Status is OK, PENDING, FAILED or MISMATCH.
+	Status string `json:"status"`
+
+	// UpToDate is true when there is nothing to apply and nothing to fix:
+	// no pending or failed migration, no checksum mismatch and a valid
+	// atlas.sum. Deploy pipelines can gate on this single field.
+	UpToDate bool `json:"up_to_date"`
+
+	CurrentVersion string `json:"current_version"`
+	NextVersion    string `json:"next_version"`
+
+	Applied    []appliedMigration `json:"applied"`
+	Pending    []pendingMigration `json:"pending"`
+	Mismatches []statusMismatch   `json:"mismatches"`
+	Failed     *failedMigration   `json:"failed"`
+
+	AtlasSum atlasSumStatus `json:"atlas_sum"`
+}
+
+type appliedMigration struct {
+	Version         string    `json:"version"`
+	Description     string    `json:"description"`
+	File            string    `json:"file"`
+	ExecutedAt      time.Time `json:"executed_at"`
+	ExecutionTimeMS float64   `json:"execution_time_ms"`
+	Hash            string    `json:"hash"`
+}
+
+type pendingMigration struct {
+	Version     string `json:"version"`
+	Description string `json:"description"`
+	File        string `json:"file"`
+	Hash        string `json:"hash"`
+}
+
+// statusMismatch is an applied migration whose file no longer matches what
+// was recorded in the database. Reason is "modified" or "missing".
+type statusMismatch struct {
+	Version     string `json:"version"`
+	File        string `json:"file"`
+	Reason      string `json:"reason"`
+	AppliedHash string `json:"applied_hash"`
+	FileHash    string `json:"file_hash"`
+}
+
+type failedMigration struct {
+	Version   string `json:"version"`
+	Error     string `json:"error"`
+	Statement string `json:"statement"`
+}
+
+type atlasSumStatus struct {
+	Valid bool   `json:"valid"`
+	Error string `json:"error,omitempty"`
+}
+
+// buildStatusReport compares the migrations directory with the revisions
+// recorded in the database.
+func buildStatusReport(engine, env string, revs map[string]revision) (statusReport, error) {
+	report := statusReport{
+		Engine:     engine,
+		Env:        env,
+		Applied:    []appliedMigration{},
+		Pending:    []pendingMigration{},
+		Mismatches: []statusMismatch{},
+		AtlasSum:   atlasSumStatus{Valid: true},
+	}
+
+	files, err := readMigrations(migrationsDir)
+	if err != nil {
+		return report, err
+	}
+	if err := verifyAtlasSum(migrationsDir); err != nil {
+		report.AtlasSum = atlasSumStatus{Error: stripANSI(err.Error())}
+	}
+
+	// atlas.sum hashes are chained, so a single edited file changes the hash
+	// of every later one. Only the first divergence is a real modification.
+	modified := false
+
+	onDisk := map[string]bool{}
+	for _, f := range files {
+		onDisk[f.Version] = true
+
+		r, ok := revs[f.Version]
+		switch {
+		case ok && r.done():
+			report.CurrentVersion = f.Version
+			report.Applied = append(report.Applied, appliedMigration{
+				Version:         f.Version,
+				Description:     f.Description,
+				File:            f.Name,
+				ExecutedAt:      r.ExecutedAt,
+				ExecutionTimeMS: float64(r.ExecutionTime) / float64(time.Millisecond),
+				Hash:            r.Hash,
+			})
+			if !modified && r.Type&revisionTypeBaseline == 0 && r.Hash != f.Hash {
+				modified = true
+				report.Mismatches = append(report.Mismatches, statusMismatch{
+					Version:     f.Version,
+					File:        f.Name,
+					Reason:      "modified",
+					AppliedHash: r.Hash,
+					FileHash:    f.Hash,
+				})
+			}
+		default:
+			if ok && r.Error != "" {
+				report.Failed = &failedMigration{
+					Version:   r.Version,
+					Error:     r.Error,
+					Statement: strings.TrimSpace(r.ErrorStmt),
+				}
+			}
+			report.Pending = append(report.Pending, pendingMigration{
+				Version:     f.Version,
+				Description: f.Description,
+				File:        f.Name,
+				Hash:        f.Hash,
+			})
+		}
+	}
+
+	// Applied versions whose file was deleted from the directory.
+	for _, r := range sortedRevisions(revs) {
+		if !onDisk[r.Version] && r.done() && r.Type&revisionTypeBaseline == 0 {
+			report.Mismatches = append(report.Mismatches, statusMismatch{
+				Version:     r.Version,
+				File:        r.Version + "_" + r.Description + ".sql",
+				Reason:      "missing",
+				AppliedHash: r.Hash,
+			})
+		}
+	}
+
+	if len(report.Pending) > 0 {
+		report.NextVersion = report.Pending[0].Version
+	}
+
+	switch {
+	case report.Failed != nil:
+		report.Status = "FAILED"
+	case len(report.Mismatches) > 0 || !report.AtlasSum.Valid:
+		report.Status = "MISMATCH"
+	case len(report.Pending) > 0:
+		report.Status = "PENDING"
+	default:
+		report.Status = "OK"
+	}
+	report.UpToDate = report.Status == "OK"
+
+	return report, nil
+}
+
+// sortedRevisions returns revs in version order.
+func sortedRevisions(revs map[string]revision) []revision {
+	out := make([]revision, 0, len(revs))
+	for _, r := range revs {
+		out = append(out, r)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Version < out[j].Version })
+	return out
+}
+
+// printStatusReport writes report to stdout as indented JSON.
+func printStatusReport(report statusReport) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
+
+// ──────────────────────────────────────────────
+// Revisions per engine
+// ──────────────────────────────────────────────
+
+// nativeRevisions reads the revisions table through the native engine.
+func nativeRevisions(db databaseConfig, env string) (map[string]revision, error) {
+	url, err := db.EnvURL(env)
+	if err != nil {
+		return nil, err
+	}
+	m, err := openNativeMigrator(url, os.Stderr)
+	if err != nil {
+		return nil, err
+	}
+	defer m.Close()
+
+	return m.appliedRevisions(context.Background())
+}
+
+// atlasStatusJSON is the part of Atlas's status report read by grove.
+type atlasStatusJSON struct {
+	Applied []struct {
+		Version       string
+		Description   string
+		Type          json.RawMessage
+		Applied       int
+		Total         int
+		ExecutedAt    time.Time
+		ExecutionTime time.Duration
+		Error         string
+		ErrorStmt     string
+		Hash          string
+	}
+}
+
+// atlasRevisions asks the Atlas CLI for the applied revisions of env.
+func atlasRevisions(env string) (map[string]revision, error) {
+	if _, err := exec.LookPath("atlas"); err != nil {
+		return nil, fmt.Errorf(
+			"atlas CLI not found in PATH\n\n  Install it from: %s",
+			colorCyan+"https://atlasgo.io/docs"+colorReset,
+		)
+	}
+
+	var stdout bytes.Buffer
+	c := exec.Command("atlas",
+		"migrate", "status",
+		"--env", env,
+		"--format", "{{ json . }}",
+	)
+	c.Stdout = &stdout
+	c.Stderr = os.Stderr
+	if err := c.Run(); err != nil {
+		return nil, fmt.Errorf("failed to check migration status: %w", err)
+	}
+
+	var status atlasStatusJSON
+	if err := json.Unmarshal(stdout.Bytes(), &status); err != nil {
+		return nil, fmt.Errorf("failed to parse atlas migrate status output: %w", err)
+	}
+
+	revs := map[string]revision{}
+	for _, a := range status.Applied {
+		revs[a.Version] = revision{
+			Version:       a.Version,
+			Description:   a.Description,
+			Type:          atlasRevisionType(a.Type),
+			Applied:       a.Applied,
+			Total:         a.Total,
+			ExecutedAt:    a.ExecutedAt,
+			ExecutionTime: a.ExecutionTime,
+			Error:         a.Error,
+			ErrorStmt:     a.ErrorStmt,
+			Hash:          a.Hash,
+		}
+	}
+	return revs, nil
+}
+
+// atlasRevisionType decodes a revision type, which Atlas versions report
+// either as the raw bit flags or as text ("baseline", "execute", …).
+func atlasRevisionType(raw json.RawMessage) int {
+	var n int
+	if err := json.Unmarshal(raw, &n); err == nil {
+		return n
+	}
+	var s string
+	_ = json.Unmarshal(raw, &s)
+	t := 0
+	if strings.Contains(s, "baseline") {
+		t |= revisionTypeBaseline
+	}
+	if strings.Contains(s, "execute") || strings.Contains(s, "applied") {
+		t |= revisionTypeExecute
+	}
+	if strings.Contains(s, "resolved") {
+		t |= revisionTypeResolved
+	}
+	return t
+}
+
+var ansiPattern = regexp.MustCompile("\033\\[[0-9;]*m")
+
+// stripANSI removes colour codes from messages embedded in JSON output.
+func stripANSI(s string) string {
+	return ansiPattern.ReplaceAllString(s, "")
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ──────────────────────────────────────────────
+// Migrations directory
+// ──────────────────────────────────────────────
+
+// migrationsDir is the Atlas migrations directory of a Grove project.
+const migrationsDir = "migrations"
+
+// atlasSumFile is the integrity file Atlas keeps next to the migrations.
+const atlasSumFile = "atlas.sum"
+
+// migrationFiles returns the names of the .sql files in dir, sorted by
+// version (the timestamp prefix sorts lexically). A missing directory yields
+// an empty list.
+func migrationFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), ".sql") {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// newMigrationName returns the file name for a new migration in Atlas's
+// "<UTC timestamp>_<name>.sql" format.
+func newMigrationName(name string) string {
+	return time.Now().UTC().Format("20060102150405") + "_" + name + ".sql"
+}
+
+// ──────────────────────────────────────────────
+// atlas.sum
+// ──────────────────────────────────────────────
+
+// migrationFile is a single versioned migration of the migrations directory.
+type migrationFile struct {
+	// Name is the file name, e.g. "20240101120000_create_users.sql".
+	Name string
+
+	// Version and Description are the parts of Name around the first "_".
+	Version     string
+	Description string
+
+	Content []byte
+
+	// Hash is the file's entry in atlas.sum (without the "h1:" prefix).
+	Hash string
+}
+
+// readMigrations loads the migrations in dir in version order and computes
+// their atlas.sum hashes exactly like "atlas migrate hash": every hash is a
+// running SHA-256 over the names and contents of all files up to and
+// including it.
+func readMigrations(dir string) ([]migrationFile, error) {
+	names, err := migrationFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []migrationFile
+	running := sha256.New()
+	for _, name := range names {
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		running.Write([]byte(name))
+		running.Write(content)
+
+		version, description, _ := strings.Cut(strings.TrimSuffix(name, ".sql"), "_")
+		files = append(files, migrationFile{
+			Name:        name,
+			Version:     version,
+			Description: description,
+			Content:     content,
+			Hash:        base64.StdEncoding.EncodeToString(running.Sum(nil)),
+		})
+	}
+	return files, nil
+}
+
+// atlasSum computes the content of atlas.sum for dir: the first line sums
+// the per-file entries, followed by one "<name> h1:<hash>" line per file.
+func atlasSum(dir string) ([]byte, error) {
+	files, err := readMigrations(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	total := sha256.New()
+	var body bytes.Buffer
+	for _, f := range files {
+		total.Write([]byte(f.Name))
+		total.Write([]byte(f.Hash))
+		fmt.Fprintf(&body, "%s h1:%s\n", f.Name, f.Hash)
+	}
+
+	return []byte(
+		"h1:" + base64.StdEncoding.EncodeToString(total.Sum(nil)) + "\n" + body.String(),
+	), nil
+}
+
+// writeAtlasSum recomputes and writes dir/atlas.sum.
+func writeAtlasSum(dir string) error {
+	sum, err := atlasSum(dir)
+	if err != nil {
+		return err
+	}
+	return writeFile(filepath.Join(dir, atlasSumFile), sum)
+}
+
+// verifyAtlasSum fails when dir/atlas.sum is missing or does not match the
+// migration files — the same integrity check Atlas runs before applying.
+func verifyAtlasSum(dir string) error {
+	want, err := atlasSum(dir)
+	if err != nil {
+		return err
+	}
+
+	got, err := os.ReadFile(filepath.Join(dir, atlasSumFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			names, _ := migrationFiles(dir)
+			if len(names) == 0 {
+				return nil
+			}
+			return fmt.Errorf(
+				"%s is missing — run %s to create it",
+				filepath.Join(dir, atlasSumFile),
+				colorGreen+"grove migrate:hash"+colorReset,
+			)
+		}
+		return fmt.Errorf("failed to read %s: %w", atlasSumFile, err)
+	}
+
+	if !bytes.Equal(bytes.TrimSpace(got), bytes.TrimSpace(want)) {
+		return fmt.Errorf(
+			"checksum mismatch in %s — a migration was added or edited by hand\n\n  If the change is intended, run %s",
+			filepath.Join(dir, atlasSumFile),
+			colorGreen+"grove migrate:hash"+colorReset,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ──────────────────────────────────────────────
+// Release builds
+// ──────────────────────────────────────────────
+//
+// grove build applies the [build] section of grove.toml to every build and,
+// when the project has an internal/version package, stamps it with the
+// version, commit and date taken from git. --release cross-compiles every
+// app for each [build] target into dist/ and writes dist/checksums.txt.
+
+// distDir receives the artifacts of grove build --release.
+const distDir = "dist"
+
+// checksumsFile lists the SHA256 of every artifact, in sha256sum format.
+const checksumsFile = "checksums.txt"
+
+// versionPkgPath is the package the version flags are injected into.
+var versionPkgPath = filepath.Join("internal", "version", "version.go")
+
+// buildTarget is one GOOS/GOARCH pair of the release matrix.
+type buildTarget struct {
+	OS   string
+	Arch string
+}
+
+func (t buildTarget) String() string { return t.OS + "/" + t.Arch }
+
+// parseTarget splits "linux/amd64" into its GOOS and GOARCH.
+func parseTarget(s string) (goos, goarch string, ok bool) {
+	goos, goarch, ok = strings.Cut(s, "/")
+	if !ok || goos == "" || goarch == "" || strings.Contains(goarch, "/") {
+		return "", "", false
+	}
+	return goos, goarch, true
+}
+
+// releaseTargets returns the [build] targets, or the host platform when none
+// are configured. The targets were validated by loadProjectConfig.
+func releaseTargets(cfg buildConfig) []buildTarget {
+	if len(cfg.Targets) == 0 {
+		return []buildTarget{{OS: runtime.GOOS, Arch: runtime.GOARCH}}
+	}
+	targets := make([]buildTarget, 0, len(cfg.Targets))
+	for _, t := range cfg.Targets {
+		goos, goarch, _ := parseTarget(t)
+		targets = append(targets, buildTarget{OS: goos, Arch: goarch})
+	}
+	return targets
+}
+
+// artifactName returns <app>_<os>_<arch>, with .exe for Windows.
+func artifactName(app string, t buildTarget) string {
+	name := app + "_" + t.OS + "_" + t.Arch
+	if t.OS == "windows" {
+		name += ".exe"
+	}
+	return name
+}
+
+// ──────────────────────────────────────────────
+// Version stamping
+// ──────────────────────────────────────────────
+
+// versionInfo is what gets injected into internal/version.
+type versionInfo struct {
+	Version string
+	Commit  string
+	Date    string
+}
+
+// gitVersion describes HEAD. The date is the commit date rather than the
+// time of the build, so rebuilding the same commit yields the same binary;
+// SOURCE_DATE_EPOCH overrides it. Outside a git repository the fields keep
+// the defaults of the version package.
+func gitVersion() versionInfo {
+	v := versionInfo{Version: "dev", Commit: "none", Date: "unknown"}
+
+	if out, err := gitOutput("describe", "--tags", "--always", "--dirty"); err == nil {
+		v.Version = out
+	}
+	if out, err := gitOutput("rev-parse", "HEAD"); err == nil {
+		v.Commit = out
+	}
+	if out, err := gitOutput("log", "-1", "--format=%ct"); err == nil {
+		if sec, err := strconv.ParseInt(out, 10, 64); err == nil {
+			v.Date = time.Unix(sec, 0).UTC().Format(time.RFC3339)
+		}
+	}
+	if epoch := os.Getenv("SOURCE_DATE_EPOCH"); epoch != "" {
+		if sec, err := strconv.ParseInt(epoch, 10, 64); err == nil {
+			v.Date = time.Unix(sec, 0).UTC().Format(time.RFC3339)
+		}
+	}
+	return v
+}
+
+func gitOutput(args ...string) (string, error) {
+	out, err := exec.Command("git", args...).Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// scaffoldVersionPackage creates internal/version/version.go when the
+// project has none yet.
+func scaffoldVersionPackage() error {
+	if fileExists(versionPkgPath) {
+		return nil
+	}
+
+	content, err := renderStub(versionStub, "version", nil)
+	if err != nil {
+		return err
+	}
+
+	return emitFile("Package", "version", versionPkgPath, content)
+}
+
+// ──────────────────────────────────────────────
+// go build invocation
+// ──────────────────────────────────────────────
+
+// goBuildArgs returns the "go build" arguments compiling main to output with
+// the [build] settings. v is injected into internal/version when non-nil.
+func goBuildArgs(cfg buildConfig, output, main string, v *versionInfo) []string {
+	args := []string{"build", "-o", output}
+	if cfg.TrimPath {
+		args = append(args, "-trimpath")
+	}
+	if len(cfg.Tags) > 0 {
+		args = append(args, "-tags", strings.Join(cfg.Tags, ","))
+	}
+
+	ldflags := strings.Fields(cfg.LDFlags)
+	if v != nil {
+		pkg := getModuleName() + "/internal/version"
+		ldflags = append(ldflags,
+			"-X", pkg+".Version="+v.Version,
+			"-X", pkg+".Commit="+v.Commit,
+			"-X", pkg+".Date="+v.Date,
+		)
+	}
+	if len(ldflags) > 0 {
+		args = append(args, "-ldflags", strings.Join(ldflags, " "))
+	}
+
+	return append(args, main)
+}
+
+// buildEnv returns the environment of a build for target t (nil for the
+// host platform).
+func buildEnv(cfg buildConfig, t *buildTarget) []string {
+	env := os.Environ()
+	if t != nil {
+		env = append(env, "GOOS="+t.OS, "GOARCH="+t.Arch)
+	}
+	if cfg.CGO != nil {
+		enabled := "0"
+		if *cfg.CGO {
+			enabled = "1"
+		}
+		env = append(env, "CGO_ENABLED="+enabled)
+	}
+	return env
+}
+
+// ──────────────────────────────────────────────
+// Checksums
+// ──────────────────────────────────────────────
+
+// cleanRelease removes the artifacts of the previous release from dir, as
+// listed in its checksums.txt, and the list itself. Other files are kept:
+// dist/ often holds a frontend build too.
+func cleanRelease(dir string) error {
+	path := filepath.Join(dir, checksumsFile)
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	for _, line := range strings.Split(string(raw), "\n") {
+		_, name, ok := strings.Cut(line, "  ")
+		if !ok || name == "" || name != filepath.Base(name) {
+			continue
+		}
+		if err := os.Remove(filepath.Join(dir, name)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return os.Remove(path)
+}
+
+// writeChecksums writes dir/checksums.txt with the SHA256 of each artifact
+// (file names relative to dir), verifiable with "sha256sum -c".
+func writeChecksums(dir string, artifacts []string) error {
+	var b strings.Builder
+	for _, name := range artifacts {
+		sum, err := fileSHA256(filepath.Join(dir, name))
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(&b, "%s  %s\n", sum, name)
+	}
+
+	return writeFile(filepath.Join(dir, checksumsFile), []byte(b.String()))
+}
+
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("failed to hash %s: %w", path, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,536 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// fuegoImportPath is the import path of the fuego framework used by the
+// generated controllers and the project's route registration.
+const fuegoImportPath = "github.com/go-fuego/fuego"
+
+// routesDir is the package where a Grove project wires controllers to routes.
+var routesDir = filepath.Join("internal", "routes")
+
+// ──────────────────────────────────────────────
+// CRUD route table
+// ──────────────────────────────────────────────
+
+// resourceRoute is a single fuego route generated for a controller.
+type resourceRoute struct {
+	Method  string // fuego helper name: Get, Post, Put, Delete
+	Path    string
+	Handler string // controller function name, e.g. "GetPost"
+}
+
+// resourceRoutes returns the five CRUD routes served by the handlers in
+// controller.stub, in the order they are registered.
+func resourceRoutes(name string) []resourceRoute {
+	snake := toSnakeCase(name)
+	base := "/" + toPlural(snake)
+	item := base + "/{" + snake + "_id}"
+
+	return []resourceRoute{
+		{Method: "Get", Path: item, Handler: "Get" + name},
+		{Method: "Get", Path: base, Handler: "List" + name + "s"},
+		{Method: "Post", Path: base, Handler: "Create" + name},
+		{Method: "Put", Path: item, Handler: "Update" + name},
+		{Method: "Delete", Path: item, Handler: "Delete" + name},
+	}
+}
+
+// routeCall renders a route as the Go statement that registers it.
+func (r resourceRoute) routeCall(fuegoPkg, server, controllersPkg string) string {
+	return fmt.Sprintf(
+		"%s.%s(%s, %s, %s.%s)",
+		fuegoPkg, r.Method, server, strconv.Quote(r.Path), controllersPkg, r.Handler,
+	)
+}
+
+// printRouteHint prints the manual registration snippet for name. It is the
+// fallback when the routes file cannot be updated automatically.
+func printRouteHint(name string) {
+	for _, r := range resourceRoutes(name) {
+		fmt.Printf(
+			"             %s%s%s\n",
+			colorGray, r.routeCall("fuego", "s", "controllers"), colorReset,
+		)
+	}
+}
+
+// ──────────────────────────────────────────────
+// Routes file editing
+// ──────────────────────────────────────────────
+
+// routesEdit is the result of planning a route registration or removal: the
+// file to change, its new content and how many routes were added or removed.
+type routesEdit struct {
+	Path    string
+	Content []byte
+	Added   int
+	Removed int
+}
+
+// registerRoutes inserts the CRUD routes for name into the project's routes
+// file and prints the outcome. Routes whose handler is already referenced in
+// the file are left alone, so running it twice is a no-op.
+//
+// A missing or unrecognised routes file is not an error: a warning and the
+// manual snippet are printed instead so scaffolding still succeeds.
+func registerRoutes(name string) error {
+	edit, err := planRoutes(name)
+	if err != nil {
+		fmt.Println(warn("Could not register routes automatically: " + err.Error()))
+		fmt.Printf("    %sAdd them by hand:%s\n", colorGray, colorReset)
+		printRouteHint(name)
+		return nil
+	}
+
+	if edit.Added == 0 {
+		if dryRun {
+			printPlanned("Routes", name, edit.Path, "skip")
+			return nil
+		}
+		printSkipped("Routes", name, edit.Path)
+		return nil
+	}
+
+	if dryRun {
+		return previewFile("Routes", name, edit.Path, edit.Content, "update")
+	}
+
+	if err := writeFile(edit.Path, edit.Content); err != nil {
+		return err
+	}
+
+	printUpdated("Routes", name, edit.Path)
+	return nil
+}
+
+// planRoutes computes the routes file edit for name without touching disk.
+//
+// The target is the first function in internal/routes/ that takes a
+// *fuego.Server parameter. New calls are appended at the end of its body
+// (before a trailing return), using the same receiver expression as the
+// existing fuego calls so route groups are respected. The edit is done by
+// splicing text at AST positions and re-parsing the result, which keeps
+// comments and formatting of the rest of the file intact.
+func planRoutes(name string) (routesEdit, error) {
+	path, fset, file, fn, err := findRoutesFunc()
+	if err != nil {
+		return routesEdit{}, err
+	}
+
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return routesEdit{}, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	fuegoPkg := importName(file, fuegoImportPath, "fuego")
+	controllersPath := getModuleName() + "/internal/controllers"
+	controllersPkg := importName(file, controllersPath, "")
+	needsImport := controllersPkg == ""
+	if needsImport {
+		controllersPkg = "controllers"
+	}
+
+	// Skip routes whose handler is already referenced anywhere in the file.
+	registered := referencedSelectors(file, controllersPkg)
+	var missing []resourceRoute
+	for _, r := range resourceRoutes(name) {
+		if !registered[r.Handler] {
+			missing = append(missing, r)
+		}
+	}
+	if len(missing) == 0 {
+		return routesEdit{Path: path, Content: src}, nil
+	}
+
+	server := routeReceiver(fn, fuegoPkg)
+
+	// Insert before a trailing return statement, otherwise before the
+	// closing brace of the function body.
+	stmts := fn.Body.List
+	insertAt := lineStart(src, fset.Position(fn.Body.Rbrace).Offset)
+	trailingReturn := false
+	if n := len(stmts); n > 0 {
+		if ret, ok := stmts[n-1].(*ast.ReturnStmt); ok {
+			insertAt = lineStart(src, fset.Position(ret.Pos()).Offset)
+			stmts = stmts[:n-1]
+			trailingReturn = true
+		}
+	}
+
+	var block strings.Builder
+	if len(stmts) > 0 {
+		block.WriteString("\n")
+	}
+	for _, r := range missing {
+		block.WriteString("\t" + r.routeCall(fuegoPkg, server, controllersPkg) + "\n")
+	}
+	if trailingReturn {
+		block.WriteString("\n")
+	}
+
+	edits := []textEdit{{Offset: insertAt, Text: block.String()}}
+
+	if needsImport {
+		edits = append(edits, importEdit(fset, file, src, controllersPath))
+	}
+
+	out := applyTextEdits(src, edits)
+
+	// Re-parse to guarantee we never write a broken file.
+	formatted, err := format.Source(out)
+	if err != nil {
+		return routesEdit{}, fmt.Errorf("edited %s does not parse: %w", path, err)
+	}
+
+	return routesEdit{Path: path, Content: formatted, Added: len(missing)}, nil
+}
+
+// planRoutesRemoval computes the routes file edit that removes every
+// statement registering one of the CRUD handlers for name — the inverse of
+// planRoutes.
+func planRoutesRemoval(name string) (routesEdit, error) {
+	path, fset, file, _, err := findRoutesFunc()
+	if err != nil {
+		return routesEdit{}, err
+	}
+
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return routesEdit{}, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	controllersPkg := importName(
+		file, getModuleName()+"/internal/controllers", "controllers",
+	)
+
+	handlers := map[string]bool{}
+	for _, r := range resourceRoutes(name) {
+		handlers[r.Handler] = true
+	}
+
+	var edits []textEdit
+	// braces are the closing braces of the blocks whose last statements are
+	// removed, so the blank line left above them can be dropped.
+	var braces []int
+	ast.Inspect(file, func(n ast.Node) bool {
+		block, ok := n.(*ast.BlockStmt)
+		if !ok {
+			return true
+		}
+		removedLast := false
+		for _, stmt := range block.List {
+			expr, ok := stmt.(*ast.ExprStmt)
+			if !ok || !callReferences(expr.X, controllersPkg, handlers) {
+				removedLast = false
+				continue
+			}
+			start := lineStart(src, fset.Position(stmt.Pos()).Offset)
+			end := lineEnd(src, fset.Position(stmt.End()).Offset)
+			edits = append(edits, textEdit{Offset: start, Delete: end - start})
+			removedLast = true
+		}
+		if removedLast {
+			braces = append(braces, fset.Position(block.Rbrace).Offset)
+		}
+		return true
+	})
+
+	if len(edits) == 0 {
+		return routesEdit{Path: path, Content: src}, nil
+	}
+
+	// Locate the braces in the edited source before removing the blank
+	// lines above them.
+	for i, off := range braces {
+		for _, e := range edits {
+			if e.Offset < off {
+				braces[i] -= e.Delete - len(e.Text)
+			}
+		}
+	}
+	out := trimBlankLinesBefore(applyTextEdits(src, edits), braces)
+
+	// Drop the controllers import when nothing else in the file uses it,
+	// otherwise the routes package would no longer compile.
+	fset = token.NewFileSet()
+	edited, err := parser.ParseFile(fset, path, out, parser.ParseComments)
+	if err != nil {
+		return routesEdit{}, fmt.Errorf("edited %s does not parse: %w", path, err)
+	}
+	if len(referencedSelectors(edited, controllersPkg)) == 0 {
+		for _, imp := range edited.Imports {
+			if p, _ := strconv.Unquote(imp.Path.Value); p != getModuleName()+"/internal/controllers" {
+				continue
+			}
+			start := lineStart(out, fset.Position(imp.Pos()).Offset)
+			end := lineEnd(out, fset.Position(imp.End()).Offset)
+			out = applyTextEdits(out, []textEdit{{Offset: start, Delete: end - start}})
+		}
+	}
+
+	formatted, err := format.Source(out)
+	if err != nil {
+		return routesEdit{}, fmt.Errorf("edited %s does not parse: %w", path, err)
+	}
+
+	return routesEdit{Path: path, Content: formatted, Removed: len(edits)}, nil
+}
+
+// findRoutesFunc parses every non-test Go file in internal/routes/ and
+// returns the first function declaring a *fuego.Server parameter. Files are
+// scanned in name order so the choice is deterministic.
+func findRoutesFunc() (string, *token.FileSet, *ast.File, *ast.FuncDecl, error) {
+	entries, err := os.ReadDir(routesDir)
+	if err != nil {
+		return "", nil, nil, nil, fmt.Errorf("%s not found", routesDir+"/")
+	}
+
+	var names []string
+	for _, e := range entries {
+		n := e.Name()
+		if !e.IsDir() && strings.HasSuffix(n, ".go") && !strings.HasSuffix(n, "_test.go") {
+			names = append(names, n)
+		}
+	}
+	sort.Strings(names)
+
+	for _, n := range names {
+		path := filepath.Join(routesDir, n)
+		fset := token.NewFileSet()
+		file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+		if err != nil {
+			return "", nil, nil, nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+
+		fuegoPkg := importName(file, fuegoImportPath, "")
+		if fuegoPkg == "" {
+			continue
+		}
+
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Body == nil {
+				continue
+			}
+			if serverParam(fn, fuegoPkg) != "" {
+				return path, fset, file, fn, nil
+			}
+		}
+	}
+
+	return "", nil, nil, nil, fmt.Errorf(
+		"no function taking a *fuego.Server found in %s",
+		routesDir+"/",
+	)
+}
+
+// serverParam returns the name of fn's *<fuegoPkg>.Server parameter, or ""
+// when it has none.
+func serverParam(fn *ast.FuncDecl, fuegoPkg string) string {
+	for _, field := range fn.Type.Params.List {
+		star, ok := field.Type.(*ast.StarExpr)
+		if !ok {
+			continue
+		}
+		sel, ok := star.X.(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != "Server" {
+			continue
+		}
+		if pkg, ok := sel.X.(*ast.Ident); ok && pkg.Name == fuegoPkg && len(field.Names) > 0 {
+			return field.Names[0].Name
+		}
+	}
+	return ""
+}
+
+// routeReceiver returns the first-argument expression most used by the
+// fuego route calls already in fn (e.g. "api" for a route group), falling
+// back to the *fuego.Server parameter name.
+func routeReceiver(fn *ast.FuncDecl, fuegoPkg string) string {
+	counts := map[string]int{}
+	best := ""
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) == 0 {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		if pkg, ok := sel.X.(*ast.Ident); !ok || pkg.Name != fuegoPkg {
+			return true
+		}
+		switch sel.Sel.Name {
+		case "Get", "Post", "Put", "Patch", "Delete":
+		default:
+			return true
+		}
+		if id, ok := call.Args[0].(*ast.Ident); ok {
+			counts[id.Name]++
+			if counts[id.Name] > counts[best] {
+				best = id.Name
+			}
+		}
+		return true
+	})
+	if best != "" {
+		return best
+	}
+	return serverParam(fn, fuegoPkg)
+}
+
+// referencedSelectors returns the set of <pkg>.X selector names used in file.
+func referencedSelectors(file *ast.File, pkg string) map[string]bool {
+	out := map[string]bool{}
+	ast.Inspect(file, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if id, ok := sel.X.(*ast.Ident); ok && id.Name == pkg {
+				out[sel.Sel.Name] = true
+			}
+		}
+		return true
+	})
+	return out
+}
+
+// callReferences reports whether expr is a call with a <pkg>.<handler>
+// argument for one of handlers.
+func callReferences(expr ast.Expr, pkg string, handlers map[string]bool) bool {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	for _, arg := range call.Args {
+		sel, ok := arg.(*ast.SelectorExpr)
+		if !ok {
+			continue
+		}
+		if id, ok := sel.X.(*ast.Ident); ok && id.Name == pkg && handlers[sel.Sel.Name] {
+			return true
+		}
+	}
+	return false
+}
+
+// importName returns the local name under which file imports path, or
+// fallback when the path is not imported.
+func importName(file *ast.File, path, fallback string) string {
+	for _, imp := range file.Imports {
+		p, err := strconv.Unquote(imp.Path.Value)
+		if err != nil || p != path {
+			continue
+		}
+		if imp.Name != nil {
+			return imp.Name.Name
+		}
+		return p[strings.LastIndex(p, "/")+1:]
+	}
+	return fallback
+}
+
+// importEdit returns the text edit that adds path to file's imports. The
+// first import declaration is extended (a single-line import is turned into
+// a parenthesised block); a file without imports gets a new declaration
+// after the package clause.
+func importEdit(fset *token.FileSet, file *ast.File, src []byte, path string) textEdit {
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+		if gen.Lparen.IsValid() {
+			return textEdit{
+				Offset: lineStart(src, fset.Position(gen.Rparen).Offset),
+				Text:   "\t" + strconv.Quote(path) + "\n",
+			}
+		}
+		spec := gen.Specs[0].(*ast.ImportSpec)
+		start := fset.Position(spec.Pos()).Offset
+		end := fset.Position(spec.End()).Offset
+		return textEdit{
+			Offset: start,
+			Delete: end - start,
+			Text:   "(\n\t" + string(src[start:end]) + "\n\t" + strconv.Quote(path) + "\n)",
+		}
+	}
+	return textEdit{
+		Offset: lineEnd(src, fset.Position(file.Name.End()).Offset),
+		Text:   "\nimport " + strconv.Quote(path) + "\n",
+	}
+}
+
+// ──────────────────────────────────────────────
+// Text splicing
+// ──────────────────────────────────────────────
+
+// textEdit replaces Delete bytes at Offset with Text.
+type textEdit struct {
+	Offset int
+	Delete int
+	Text   string
+}
+
+// applyTextEdits applies non-overlapping edits to src. Edits are applied
+// from the end of the file backwards so earlier offsets stay valid.
+func applyTextEdits(src []byte, edits []textEdit) []byte {
+	sort.Slice(edits, func(i, j int) bool { return edits[i].Offset > edits[j].Offset })
+	out := append([]byte(nil), src...)
+	for _, e := range edits {
+		tail := append([]byte(e.Text), out[e.Offset+e.Delete:]...)
+		out = append(out[:e.Offset], tail...)
+	}
+	return out
+}
+
+// trimBlankLinesBefore removes the blank lines directly above the line
+// containing each offset in offs.
+func trimBlankLinesBefore(src []byte, offs []int) []byte {
+	sort.Sort(sort.Reverse(sort.IntSlice(offs)))
+	for _, off := range offs {
+		end := lineStart(src, off)
+		start := end
+		for start > 0 {
+			prev := lineStart(src, start-1)
+			if len(bytes.TrimSpace(src[prev:start])) > 0 {
+				break
+			}
+			start = prev
+		}
+		src = append(src[:start], src[end:]...)
+	}
+	return src
+}
+
+// lineStart returns the offset of the first byte of the line containing off.
+func lineStart(src []byte, off int) int {
+	for off > 0 && src[off-1] != '\n' {
+		off--
+	}
+	return off
+}
+
+// lineEnd returns the offset just past the newline ending the line that
+// contains off (or len(src) on the last line).
+func lineEnd(src []byte, off int) int {
+	for off < len(src) && src[off] != '\n' {
+		off++
+	}
+	if off < len(src) {
+		off++
+	}
+	return off
+}
@@ -3,10 +3,13 @@ package main
 import (
 	"bytes"
 	"fmt"
+	"go/format"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"text/template"
+
+	"github.com/spf13/cobra"
 )
 
 // gestModule is the fully-qualified module path used by go get to install or
@@ -30,188 +33,189 @@ func ensureGest() error {
 // Model
 // ──────────────────────────────────────────────
 
-func scaffoldModel(name string) error {
-	snake := toSnakeCase(name)
-	tableName := toPlural(snake)
-	destPath := filepath.Join("internal", "models", snake+".go")
+// modelPath returns internal/models/<snake>.go.
+func modelPath(name string) string {
+	return filepath.Join("internal", "models", toSnakeCase(name)+".go")
+}
 
-	if fileExists(destPath) {
-		printSkipped("Model", name, destPath)
-		return nil
+// scaffoldModel creates internal/models/<snake>.go. fields are the parsed
+// inline definitions from the command line and may be empty, in which case
+// the bare ID/timestamps skeleton is generated.
+func scaffoldModel(name string, fields []modelField) error {
+	content, err := renderModel(name, fields)
+	if err != nil {
+		return err
 	}
 
+	return emitFile("Model", name, modelPath(name), content)
+}
+
+func renderModel(name string, fields []modelField) ([]byte, error) {
+	snake := toSnakeCase(name)
+	tableName := toPlural(snake)
+
 	module := getModuleName()
 
 	data := struct {
 		Name      string
 		TableName string
 		Module    string
+		Fields    []modelField
 	}{
 		Name:      name,
 		TableName: tableName,
 		Module:    module,
+		Fields:    fields,
 	}
 
-	content, err := renderStub(modelStub, "model", data)
-	if err != nil {
-		return err
-	}
-
-	if err := writeFile(destPath, content); err != nil {
-		return err
-	}
-
-	printCreated("Model", name, destPath)
-	return nil
+	return renderStub(modelStub, "model", data)
 }
 
 // ──────────────────────────────────────────────
 // Controller
 // ──────────────────────────────────────────────
 
-func scaffoldController(name string) error {
-	snake := toSnakeCase(name)
-	kebab := toKebabCase(name)
-	destPath := filepath.Join("internal", "controllers", kebab+"-controller.go")
+// controllerPath returns internal/controllers/<kebab>-controller.go.
+func controllerPath(name string) string {
+	return filepath.Join("internal", "controllers", toKebabCase(name)+"-controller.go")
+}
 
-	if fileExists(destPath) {
-		printSkipped("Controller", name, destPath)
-		return nil
+// scaffoldController creates internal/controllers/<kebab>-controller.go.
+// When fields are given, the Create/Update handlers and the to<Name>DTO
+// mapper are generated with the field assignments instead of TODOs.
+func scaffoldController(name string, fields []modelField) error {
+	content, err := renderController(name, fields)
+	if err != nil {
+		return err
 	}
 
+	return emitFile("Controller", name, controllerPath(name), content)
+}
+
+func renderController(name string, fields []modelField) ([]byte, error) {
+	snake := toSnakeCase(name)
+
 	module := getModuleName()
 
 	data := struct {
 		Name      string
 		ParamName string
 		Module    string
+		Fields    []modelField
 	}{
 		Name:      name,
 		ParamName: snake,
 		Module:    module,
+		Fields:    columnFields(fields),
 	}
 
-	content, err := renderStub(controllerStub, "controller", data)
-	if err != nil {
-		return err
-	}
-
-	if err := writeFile(destPath, content); err != nil {
-		return err
-	}
-
-	printCreated("Controller", name, destPath)
-	return nil
+	return renderStub(controllerStub, "controller", data)
 }
 
 // ──────────────────────────────────────────────
 // Request / DTO
 // ──────────────────────────────────────────────
 
-func scaffoldRequest(name string) error {
-	kebab := toKebabCase(name)
-	snake := toSnakeCase(name)
-	destPath := filepath.Join("internal", "dto", kebab+"-dto.go")
+// requestPath returns internal/dto/<kebab>-dto.go.
+func requestPath(name string) string {
+	return filepath.Join("internal", "dto", toKebabCase(name)+"-dto.go")
+}
 
-	if fileExists(destPath) {
-		printSkipped("DTO", name, destPath)
-		return nil
+// scaffoldRequest creates internal/dto/<kebab>-dto.go. Association fields
+// are left out; only real columns are exposed through the DTOs.
+func scaffoldRequest(name string, fields []modelField) error {
+	content, err := renderRequest(name, fields)
+	if err != nil {
+		return err
 	}
 
+	return emitFile("DTO", name, requestPath(name), content)
+}
+
+func renderRequest(name string, fields []modelField) ([]byte, error) {
+	snake := toSnakeCase(name)
+
+	columns := columnFields(fields)
+
 	data := struct {
 		Name      string
 		SnakeName string
+		Fields    []modelField
+		NeedsTime bool
 	}{
 		Name:      name,
 		SnakeName: snake,
+		Fields:    columns,
+		NeedsTime: fieldsNeedTime(columns),
 	}
 
-	content, err := renderStub(requestStub, "request", data)
-	if err != nil {
-		return err
-	}
-
-	if err := writeFile(destPath, content); err != nil {
-		return err
-	}
-
-	printCreated("DTO", name, destPath)
-	return nil
+	return renderStub(requestStub, "request", data)
 }
 
 // ──────────────────────────────────────────────
 // Middleware
 // ──────────────────────────────────────────────
 
-func scaffoldMiddleware(name string) error {
-	kebab := toKebabCase(name)
-	destPath := filepath.Join("internal", "middleware", kebab+"-middleware.go")
+// middlewarePath returns internal/middleware/<kebab>-middleware.go.
+func middlewarePath(name string) string {
+	return filepath.Join("internal", "middleware", toKebabCase(name)+"-middleware.go")
+}
 
-	if fileExists(destPath) {
-		printSkipped("Middleware", name, destPath)
-		return nil
+func scaffoldMiddleware(name string) error {
+	content, err := renderMiddleware(name)
+	if err != nil {
+		return err
 	}
 
+	return emitFile("Middleware", name, middlewarePath(name), content)
+}
+
+func renderMiddleware(name string) ([]byte, error) {
 	data := struct {
 		Name string
 	}{
 		Name: name,
 	}
 
-	content, err := renderStub(middlewareStub, "middleware", data)
-	if err != nil {
-		return err
-	}
-
-	if err := writeFile(destPath, content); err != nil {
-		return err
-	}
-
-	printCreated("Middleware", name, destPath)
-	return nil
+	return renderStub(middlewareStub, "middleware", data)
 }
 
 // ──────────────────────────────────────────────
 // Test spec
 // ──────────────────────────────────────────────
 
-// scaffoldTestSpec creates internal/tests/<snake>_test.go for gest v2.
+// testSpecPath returns internal/tests/<snake>_test.go.
+func testSpecPath(name string) string {
+	return filepath.Join("internal", "tests", toSnakeCase(name)+"_test.go")
+}
+
+// scaffoldTestSpec creates internal/tests/<snake>_test.go for gest v2. With
+// a model, the suite exercises the model's factory instead of an empty spec.
 // On the first call it also runs "go get" to add gest to the project's go.mod.
-func scaffoldTestSpec(name string) error {
-	snake := toSnakeCase(name)
-	destPath := filepath.Join("internal", "tests", snake+"_test.go")
+func scaffoldTestSpec(name, model string) error {
+	destPath := testSpecPath(name)
 
 	isFirstSpec := !dirHasTestFiles(filepath.Join("internal", "tests"))
-
-	if fileExists(destPath) {
-		printSkipped("Test", name, destPath)
-		return nil
+	created := !fileExists(destPath)
+
+	var (
+		content []byte
+		err     error
+	)
+	if model != "" {
+		content, err = renderModelTestSpec(name, model)
+	} else {
+		content, err = renderTestSpec(name)
 	}
-
-	pkg := getPackageName()
-
-	data := struct {
-		Name    string
-		Package string
-		Label   string
-	}{
-		Name:    name,
-		Package: pkg,
-		Label:   toWords(name),
-	}
-
-	content, err := renderStub(testSpecStub, "test_spec", data)
 	if err != nil {
 		return err
 	}
 
-	if err := writeFile(destPath, content); err != nil {
+	if err := emitFile("Test", name, destPath, content); err != nil {
 		return err
 	}
 
-	printCreated("Test", name, destPath)
-
-	if isFirstSpec {
+	if isFirstSpec && created && !dryRun {
 		// Install gest into the project's go.mod the first time a test file is
 		// created so that "go test ./..." works immediately.
 		fmt.Println()
@@ -235,6 +239,40 @@ func scaffoldTestSpec(name string) error {
 	return nil
 }
 
+func renderTestSpec(name string) ([]byte, error) {
+	pkg := getPackageName()
+
+	data := struct {
+		Name    string
+		Package string
+		Label   string
+	}{
+		Name:    name,
+		Package: pkg,
+		Label:   toWords(name),
+	}
+
+	return renderStub(testSpecStub, "test_spec", data)
+}
+
+func renderModelTestSpec(name, model string) ([]byte, error) {
+	data := struct {
+		Name   string
+		Model  string
+		Label  string
+		Plural string
+		Module string
+	}{
+		Name:   name,
+		Model:  model,
+		Label:  toWords(name),
+		Plural: toPlural(toWords(model)),
+		Module: getModuleName(),
+	}
+
+	return renderStub(testModelStub, "test_model", data)
+}
+
 // dirHasTestFiles reports whether dir contains at least one *_test.go file.
 func dirHasTestFiles(dir string) bool {
 	entries, err := os.ReadDir(dir)
@@ -250,12 +288,166 @@ func dirHasTestFiles(dir string) bool {
 	return false
 }
 
+// ──────────────────────────────────────────────
+// Plan / apply
+// ──────────────────────────────────────────────
+
+// dryRun is bound to the --dry-run flag of the commands that write files (see
+// main.go). When set, generators render everything as usual but emitFile
+// only previews the result.
+var dryRun bool
+
+// scaffoldForce and scaffoldMerge are bound to the --force / --merge flags
+// shared by every make:* generator (see addScaffoldFlags).
+var (
+	scaffoldForce bool
+	scaffoldMerge bool
+)
+
+// generatedDir keeps a pristine copy of every file grove generated, mirrored
+// by path. It is the merge base for --merge.
+var generatedDir = filepath.Join(".grove", "generated")
+
+// addScaffoldFlags registers --force and --merge on a generator command.
+func addScaffoldFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVarP(
+		&scaffoldForce,
+		"force", "f", false,
+		"Overwrite existing files with freshly generated content",
+	)
+	cmd.Flags().BoolVar(
+		&scaffoldMerge,
+		"merge", false,
+		"Three-way merge regenerated content with your edits to existing files",
+	)
+	cmd.MarkFlagsMutuallyExclusive("force", "merge")
+}
+
+// emitFile is the single point where generators hand over a rendered file.
+//
+// Existing files are skipped unless --force (overwrite) or --merge (three-way
+// merge against the content recorded at the last generation) is given. Every
+// write also records content under .grove/generated/ as the next merge base.
+//
+// In --dry-run mode nothing is written: the planned action is printed with
+// the rendered content (new files) or a unified diff against what is on disk.
+func emitFile(kind, name, path string, content []byte) error {
+	exists := fileExists(path)
+
+	switch {
+	case !exists:
+		if dryRun {
+			return previewFile(kind, name, path, content, "create")
+		}
+		if err := writeGenerated(path, content, content); err != nil {
+			return err
+		}
+		printCreated(kind, name, path)
+		return nil
+
+	case scaffoldForce:
+		if dryRun {
+			return previewFile(kind, name, path, content, "update")
+		}
+		if err := writeGenerated(path, content, content); err != nil {
+			return err
+		}
+		printUpdated(kind, name, path)
+		return nil
+
+	case scaffoldMerge:
+		base, err := os.ReadFile(generatedRecordPath(path))
+		if err != nil {
+			fmt.Println(warn("No generation record for " + path + " — cannot merge, use --force to overwrite."))
+			printSkipped(kind, name, path)
+			return nil
+		}
+		current, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		merged, conflicts := merge3(base, current, content)
+
+		if dryRun {
+			return previewFile(kind, name, path, merged, "update")
+		}
+		if err := writeGenerated(path, merged, content); err != nil {
+			return err
+		}
+		if conflicts > 0 {
+			printConflict(kind, name, path, conflicts)
+			return nil
+		}
+		printMerged(kind, name, path)
+		return nil
+
+	default:
+		if dryRun {
+			return previewFile(kind, name, path, content, "skip")
+		}
+		printSkipped(kind, name, path)
+		return nil
+	}
+}
+
+// writeGenerated writes content to path and records generated — the pristine
+// generator output — under .grove/generated/ as the next merge base.
+func writeGenerated(path string, content, generated []byte) error {
+	if err := writeFile(path, content); err != nil {
+		return err
+	}
+	return writeFile(generatedRecordPath(path), generated)
+}
+
+// generatedRecordPath returns where the pristine copy of path is recorded.
+func generatedRecordPath(path string) string {
+	return filepath.Join(generatedDir, filepath.Clean(path))
+}
+
+// previewFile prints what a generator would do with path in --dry-run mode.
+// action is one of "create", "skip" or "update".
+func previewFile(kind, name, path string, content []byte, action string) error {
+	printPlanned(kind, name, path, action)
+
+	if action == "create" {
+		for i, line := range splitLines(string(content)) {
+			fmt.Printf("    %s%4d%s  %s\n", colorGray+colorDim, i+1, colorReset, line)
+		}
+		fmt.Println()
+		return nil
+	}
+
+	current, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	diff := unifiedDiff(path+" (current)", path+" (generated)", current, content)
+	if diff == "" {
+		fmt.Printf("    %s(identical to the generated content)%s\n\n", colorGray, colorReset)
+		return nil
+	}
+	printDiff(diff)
+	fmt.Println()
+	return nil
+}
+
 // ──────────────────────────────────────────────
 // Low-level helpers
 // ──────────────────────────────────────────────
 
 // renderStub parses and executes a text/template stub with the given data.
+// A project-local .grove/stubs/<name>.stub takes precedence over the embedded
+// stub when present (see resolveStub). The result is passed through gofmt so
+// that struct fields generated from inline definitions line up; output that
+// does not parse as Go is returned unchanged.
 func renderStub(stub, name string, data any) ([]byte, error) {
+	stub, err := resolveStub(name, stub)
+	if err != nil {
+		return nil, err
+	}
+
 	tmpl, err := template.New(name).Parse(stub)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse %s stub: %w", name, err)
@@ -266,6 +458,10 @@ func renderStub(stub, name string, data any) ([]byte, error) {
 		return nil, fmt.Errorf("failed to render %s stub: %w", name, err)
 	}
 
+	if formatted, err := format.Source(buf.Bytes()); err == nil {
+		return formatted, nil
+	}
+
 	return buf.Bytes(), nil
 }
 
@@ -0,0 +1,767 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ──────────────────────────────────────────────
+// Schema model
+// ──────────────────────────────────────────────
+
+// dbSchema is the database schema described by the GORM models. It is also
+// the format of the snapshot stored in .grove/schema.json.
+type dbSchema struct {
+	Dialect string     `json:"dialect"`
+	Tables  []*dbTable `json:"tables"`
+}
+
+// dbTable is a single table with its columns, keys and indexes.
+type dbTable struct {
+	Name        string         `json:"name"`
+	Columns     []dbColumn     `json:"columns"`
+	PrimaryKey  []string       `json:"primary_key,omitempty"`
+	Indexes     []dbIndex      `json:"indexes,omitempty"`
+	ForeignKeys []dbForeignKey `json:"foreign_keys,omitempty"`
+}
+
+// dbColumn is a table column. Default holds the raw SQL expression.
+type dbColumn struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Null    bool   `json:"null,omitempty"`
+	Default string `json:"default,omitempty"`
+}
+
+// dbIndex is a (possibly unique, possibly composite) index.
+type dbIndex struct {
+	Name    string   `json:"name"`
+	Columns []string `json:"columns"`
+	Unique  bool     `json:"unique,omitempty"`
+}
+
+// dbForeignKey is a single-column foreign key constraint.
+type dbForeignKey struct {
+	Name      string `json:"name"`
+	Column    string `json:"column"`
+	RefTable  string `json:"ref_table"`
+	RefColumn string `json:"ref_column"`
+	OnUpdate  string `json:"on_update,omitempty"`
+	OnDelete  string `json:"on_delete,omitempty"`
+}
+
+func (s *dbSchema) table(name string) *dbTable {
+	for _, t := range s.Tables {
+		if t.Name == name {
+			return t
+		}
+	}
+	return nil
+}
+
+func (t *dbTable) column(name string) (dbColumn, bool) {
+	for _, c := range t.Columns {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return dbColumn{}, false
+}
+
+func (t *dbTable) index(name string) (dbIndex, bool) {
+	for _, i := range t.Indexes {
+		if i.Name == name {
+			return i, true
+		}
+	}
+	return dbIndex{}, false
+}
+
+func (t *dbTable) foreignKey(name string) (dbForeignKey, bool) {
+	for _, fk := range t.ForeignKeys {
+		if fk.Name == name {
+			return fk, true
+		}
+	}
+	return dbForeignKey{}, false
+}
+
+// ──────────────────────────────────────────────
+// Snapshot
+// ──────────────────────────────────────────────
+
+// modelsDir is where make:model puts the GORM structs.
+var modelsDir = filepath.Join("internal", "models")
+
+// schemaSnapshotPath records the schema as of the last offline migration.
+// It must be committed together with the migrations directory.
+var schemaSnapshotPath = filepath.Join(".grove", "schema.json")
+
+// loadSchemaSnapshot reads the recorded schema. The bool is false when no
+// snapshot exists yet.
+func loadSchemaSnapshot() (*dbSchema, bool, error) {
+	raw, err := os.ReadFile(schemaSnapshotPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &dbSchema{}, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to read %s: %w", schemaSnapshotPath, err)
+	}
+
+	var s dbSchema
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return nil, false, fmt.Errorf("%s is corrupt: %w", schemaSnapshotPath, err)
+	}
+	return &s, true, nil
+}
+
+// marshalSchemaSnapshot renders s in the on-disk snapshot format.
+func marshalSchemaSnapshot(s *dbSchema) ([]byte, error) {
+	raw, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(raw, '\n'), nil
+}
+
+// ──────────────────────────────────────────────
+// GORM model parsing
+// ──────────────────────────────────────────────
+
+// modelRelation is an association field found while parsing a model. Its
+// foreign key is resolved once every table is known.
+type modelRelation struct {
+	Owner string // struct declaring the field
+	Field string // association field name
+	Tag   map[string]string
+	Elem  string // associated struct
+	Many  bool   // []Elem (has_many) rather than Elem / *Elem
+}
+
+// schemaBuilder turns the structs of the models package into a dbSchema,
+// following GORM's naming and tag conventions.
+type schemaBuilder struct {
+	dialect    string
+	structs    map[string]*ast.StructType
+	named      map[string]string // local named non-struct types → underlying type
+	tableNames map[string]string // struct → TableName() override
+	tables     map[string]*dbTable
+	columns    map[string]map[string]string // struct → field → column
+	relations  []modelRelation
+}
+
+// loadModelSchema parses every non-test .go file in dir and derives the
+// schema that GORM's AutoMigrate would create for the models declared there.
+func loadModelSchema(dir, dialect string) (*dbSchema, error) {
+	b, err := parseModels(dir, dialect)
+	if err != nil {
+		return nil, err
+	}
+
+	var models []string
+	for name := range b.structs {
+		if b.isModel(name) {
+			models = append(models, name)
+		}
+	}
+	sort.Strings(models)
+
+	for _, name := range models {
+		if err := b.buildTable(name); err != nil {
+			return nil, err
+		}
+	}
+	b.resolveRelations()
+
+	s := &dbSchema{Dialect: dialect}
+	for _, name := range models {
+		t := b.tables[name]
+		sort.Slice(t.Indexes, func(i, j int) bool { return t.Indexes[i].Name < t.Indexes[j].Name })
+		sort.Slice(t.ForeignKeys, func(i, j int) bool { return t.ForeignKeys[i].Name < t.ForeignKeys[j].Name })
+		s.Tables = append(s.Tables, t)
+	}
+	sort.Slice(s.Tables, func(i, j int) bool { return s.Tables[i].Name < s.Tables[j].Name })
+	return s, nil
+}
+
+// parseModels returns a schemaBuilder holding the type declarations of every
+// non-test .go file in dir.
+func parseModels(dir, dialect string) (*schemaBuilder, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+
+	b := &schemaBuilder{
+		dialect:    dialect,
+		structs:    map[string]*ast.StructType{},
+		named:      map[string]string{},
+		tableNames: map[string]string{},
+		tables:     map[string]*dbTable{},
+		columns:    map[string]map[string]string{},
+	}
+
+	fset := token.NewFileSet()
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		path := filepath.Join(dir, name)
+		file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		b.collect(file)
+	}
+	return b, nil
+}
+
+// collect records the type declarations and TableName overrides of a file.
+func (b *schemaBuilder) collect(file *ast.File) {
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.GenDecl:
+			if d.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range d.Specs {
+				ts := spec.(*ast.TypeSpec)
+				if st, ok := ts.Type.(*ast.StructType); ok {
+					b.structs[ts.Name.Name] = st
+				} else if ts.TypeParams == nil {
+					b.named[ts.Name.Name] = exprString(ts.Type)
+				}
+			}
+
+		case *ast.FuncDecl:
+			if d.Name.Name != "TableName" || d.Recv == nil || len(d.Recv.List) != 1 || d.Body == nil {
+				continue
+			}
+			recv := strings.TrimPrefix(exprString(d.Recv.List[0].Type), "*")
+			for _, stmt := range d.Body.List {
+				ret, ok := stmt.(*ast.ReturnStmt)
+				if !ok || len(ret.Results) != 1 {
+					continue
+				}
+				if lit, ok := ret.Results[0].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+					if v, err := strconv.Unquote(lit.Value); err == nil {
+						b.tableNames[recv] = v
+					}
+				}
+			}
+		}
+	}
+}
+
+// isModel reports whether the struct is a GORM model: an exported struct
+// with a TableName method, an embedded gorm.Model or an ID field.
+func (b *schemaBuilder) isModel(name string) bool {
+	if !ast.IsExported(name) {
+		return false
+	}
+	if _, ok := b.tableNames[name]; ok {
+		return true
+	}
+	for _, f := range b.structs[name].Fields.List {
+		if len(f.Names) == 0 && exprString(f.Type) == "gorm.Model" {
+			return true
+		}
+		for _, n := range f.Names {
+			if n.Name == "ID" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// tableName returns the table for a model: its TableName() override or
+// GORM's default pluralised snake_case name.
+func (b *schemaBuilder) tableName(model string) string {
+	if name, ok := b.tableNames[model]; ok {
+		return name
+	}
+	return toPlural(toDBName(model))
+}
+
+func (b *schemaBuilder) buildTable(model string) error {
+	t := &dbTable{Name: b.tableName(model)}
+	b.tables[model] = t
+	b.columns[model] = map[string]string{}
+
+	if err := b.addFields(model, t, b.structs[model], ""); err != nil {
+		return err
+	}
+
+	// GORM falls back to a field called ID when no primaryKey tag is given.
+	if len(t.PrimaryKey) == 0 {
+		if col, ok := b.columns[model]["ID"]; ok {
+			t.PrimaryKey = []string{col}
+			for i := range t.Columns {
+				if t.Columns[i].Name == col {
+					t.Columns[i].Null = false
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// addFields adds the columns of st to t. Embedded structs are flattened,
+// association fields are recorded for resolveRelations.
+func (b *schemaBuilder) addFields(model string, t *dbTable, st *ast.StructType, prefix string) error {
+	for _, field := range st.Fields.List {
+		tag := gormTag(field)
+		if v, ok := tag["-"]; ok && (v == "" || v == "all" || v == "migration") {
+			continue
+		}
+		goType := exprString(field.Type)
+
+		if len(field.Names) == 0 {
+			if goType == "gorm.Model" {
+				b.addGormModel(model, t)
+				continue
+			}
+			if embedded, ok := b.structs[strings.TrimPrefix(goType, "*")]; ok {
+				if err := b.addFields(model, t, embedded, prefix); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		for _, ident := range field.Names {
+			if !ident.IsExported() {
+				continue
+			}
+			if err := b.addField(model, t, ident.Name, goType, tag, prefix); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (b *schemaBuilder) addField(model string, t *dbTable, name, goType string, tag map[string]string, prefix string) error {
+	base := strings.TrimPrefix(goType, "*")
+	elem := strings.TrimPrefix(strings.TrimPrefix(base, "[]"), "*")
+
+	if _, ok := tag["embedded"]; ok {
+		if embedded, ok := b.structs[elem]; ok {
+			return b.addFields(model, t, embedded, prefix+tag["embeddedprefix"])
+		}
+	}
+
+	// Associations: a field whose type is another struct of the package.
+	if _, ok := b.structs[elem]; ok && tag["serializer"] == "" && tag["type"] == "" {
+		if _, ok := tag["many2many"]; !ok {
+			b.relations = append(b.relations, modelRelation{
+				Owner: model,
+				Field: name,
+				Tag:   tag,
+				Elem:  elem,
+				Many:  strings.HasPrefix(base, "[]"),
+			})
+		}
+		return nil
+	}
+
+	column := tag["column"]
+	if column == "" {
+		column = prefix + toDBName(name)
+	}
+	b.columns[model][name] = column
+
+	_, pk := tag["primarykey"]
+	if _, ok := tag["primary_key"]; ok {
+		pk = true
+	}
+	autoIncVal, hasAutoInc := tag["autoincrement"]
+	autoInc := hasAutoInc && autoIncVal != "false"
+	_, notNull := tag["not null"]
+	_, unique := tag["unique"]
+	_, uniqueIndex := tag["uniqueindex"]
+	_, index := tag["index"]
+	keyed := pk || unique || uniqueIndex || index
+
+	typ := tag["type"]
+	if typ == "" {
+		if tag["serializer"] != "" {
+			base = "[]byte"
+			if tag["serializer"] == "json" {
+				base = "datatypes.JSON"
+			}
+		}
+		size, _ := strconv.Atoi(tag["size"])
+		// GORM treats an integer primary key as auto-increment by default.
+		if pk && !hasAutoInc && isIntegerType(b.underlying(base)) {
+			autoInc = true
+		}
+		var ok bool
+		if typ, ok = columnType(b.dialect, b.underlying(base), size, keyed, pk && autoInc); !ok {
+			return fmt.Errorf(
+				"%s.%s: cannot map Go type %s to a column — add a %s tag or %s to skip it",
+				model, name, goType,
+				colorCyan+`gorm:"type:..."`+colorReset,
+				colorCyan+`gorm:"-"`+colorReset,
+			)
+		}
+	}
+
+	t.Columns = append(t.Columns, dbColumn{
+		Name:    column,
+		Type:    typ,
+		Null:    !notNull && !pk,
+		Default: tag["default"],
+	})
+	if pk {
+		t.PrimaryKey = append(t.PrimaryKey, column)
+	}
+
+	if unique {
+		t.Indexes = append(t.Indexes, dbIndex{
+			Name:    "uni_" + t.Name + "_" + column,
+			Columns: []string{column},
+			Unique:  true,
+		})
+	}
+	for key, isUnique := range map[string]bool{"index": false, "uniqueindex": true} {
+		v, ok := tag[key]
+		if !ok {
+			continue
+		}
+		opts := strings.Split(v, ",")
+		idxName := strings.TrimSpace(opts[0])
+		if idxName == "" {
+			idxName = "idx_" + t.Name + "_" + column
+		}
+		for _, o := range opts[1:] {
+			if strings.EqualFold(strings.TrimSpace(o), "unique") {
+				isUnique = true
+			}
+		}
+		addIndexColumn(t, idxName, column, isUnique)
+	}
+	return nil
+}
+
+// addIndexColumn adds column to the named index, creating it if needed, so
+// fields sharing an index name form a composite index.
+func addIndexColumn(t *dbTable, name, column string, unique bool) {
+	for i := range t.Indexes {
+		if t.Indexes[i].Name == name {
+			t.Indexes[i].Columns = append(t.Indexes[i].Columns, column)
+			t.Indexes[i].Unique = t.Indexes[i].Unique || unique
+			return
+		}
+	}
+	t.Indexes = append(t.Indexes, dbIndex{Name: name, Columns: []string{column}, Unique: unique})
+}
+
+// addGormModel adds the columns of an embedded gorm.Model.
+func (b *schemaBuilder) addGormModel(model string, t *dbTable) {
+	idType, _ := columnType(b.dialect, "uint", 0, true, true)
+	timeType, _ := columnType(b.dialect, "time.Time", 0, false, false)
+
+	t.Columns = append(t.Columns,
+		dbColumn{Name: "id", Type: idType},
+		dbColumn{Name: "created_at", Type: timeType, Null: true},
+		dbColumn{Name: "updated_at", Type: timeType, Null: true},
+		dbColumn{Name: "deleted_at", Type: timeType, Null: true},
+	)
+	t.PrimaryKey = append(t.PrimaryKey, "id")
+	t.Indexes = append(t.Indexes, dbIndex{
+		Name:    "idx_" + t.Name + "_deleted_at",
+		Columns: []string{"deleted_at"},
+	})
+	for field, column := range map[string]string{
+		"ID": "id", "CreatedAt": "created_at", "UpdatedAt": "updated_at", "DeletedAt": "deleted_at",
+	} {
+		b.columns[model][field] = column
+	}
+}
+
+// underlying resolves named types declared in the models package, e.g.
+// "type Status string", to their underlying type.
+func (b *schemaBuilder) underlying(goType string) string {
+	for range 8 {
+		next, ok := b.named[goType]
+		if !ok {
+			break
+		}
+		goType = next
+	}
+	return goType
+}
+
+// resolveRelations adds the foreign keys GORM creates for belongs_to,
+// has_one and has_many associations. Constraints are named after the
+// declaring table and field, as GORM does: fk_<table>_<field>.
+func (b *schemaBuilder) resolveRelations() {
+	for _, rel := range b.relations {
+		owner, target := b.tables[rel.Owner], b.tables[rel.Elem]
+		if owner == nil || target == nil {
+			continue
+		}
+
+		fk := dbForeignKey{Name: "fk_" + owner.Name + "_" + toDBName(rel.Field)}
+		onUpdate, onDelete := constraintActions(rel.Tag["constraint"])
+		fk.OnUpdate, fk.OnDelete = onUpdate, onDelete
+
+		// belongs_to: the owner holds <Field>ID (or the foreignKey tag).
+		if !rel.Many {
+			fkField := rel.Tag["foreignkey"]
+			if fkField == "" {
+				fkField = rel.Field + "ID"
+			}
+			if col, ok := b.columns[rel.Owner][fkField]; ok && len(target.PrimaryKey) == 1 {
+				fk.Column = col
+				fk.RefTable = target.Name
+				fk.RefColumn = target.PrimaryKey[0]
+				if ref := b.columns[rel.Elem][rel.Tag["references"]]; ref != "" {
+					fk.RefColumn = ref
+				}
+				addForeignKey(owner, fk)
+				continue
+			}
+		}
+
+		// has_one / has_many: the associated model holds <Owner>ID.
+		fkField := rel.Tag["foreignkey"]
+		if fkField == "" {
+			fkField = rel.Owner + "ID"
+		}
+		col, ok := b.columns[rel.Elem][fkField]
+		if !ok || len(owner.PrimaryKey) != 1 {
+			continue
+		}
+		fk.Column = col
+		fk.RefTable = owner.Name
+		fk.RefColumn = owner.PrimaryKey[0]
+		if ref := b.columns[rel.Owner][rel.Tag["references"]]; ref != "" {
+			fk.RefColumn = ref
+		}
+		addForeignKey(target, fk)
+	}
+}
+
+func addForeignKey(t *dbTable, fk dbForeignKey) {
+	if _, ok := t.foreignKey(fk.Name); !ok {
+		t.ForeignKeys = append(t.ForeignKeys, fk)
+	}
+}
+
+// constraintActions parses a GORM constraint tag value such as
+// "OnUpdate:CASCADE,OnDelete:SET NULL".
+func constraintActions(v string) (onUpdate, onDelete string) {
+	for _, part := range strings.Split(v, ",") {
+		key, val, ok := strings.Cut(part, ":")
+		if !ok {
+			continue
+		}
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "onupdate":
+			onUpdate = strings.ToUpper(strings.TrimSpace(val))
+		case "ondelete":
+			onDelete = strings.ToUpper(strings.TrimSpace(val))
+		}
+	}
+	return onUpdate, onDelete
+}
+
+// gormTag parses the gorm:"…" struct tag of a field into a map keyed by the
+// lower-cased setting name, e.g. "type:uuid;not null" → {type: uuid, not null: ""}.
+func gormTag(field *ast.Field) map[string]string {
+	out := map[string]string{}
+	if field.Tag == nil {
+		return out
+	}
+	raw, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return out
+	}
+	for _, part := range strings.Split(reflect.StructTag(raw).Get("gorm"), ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		key, val, _ := strings.Cut(part, ":")
+		out[strings.ToLower(strings.TrimSpace(key))] = strings.TrimSpace(val)
+	}
+	return out
+}
+
+// exprString renders a type expression such as *time.Time or []Post.
+func exprString(e ast.Expr) string {
+	switch t := e.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.StarExpr:
+		return "*" + exprString(t.X)
+	case *ast.SelectorExpr:
+		return exprString(t.X) + "." + t.Sel.Name
+	case *ast.ArrayType:
+		if t.Len == nil {
+			return "[]" + exprString(t.Elt)
+		}
+		return "[...]" + exprString(t.Elt)
+	case *ast.IndexExpr:
+		return exprString(t.X) + "[" + exprString(t.Index) + "]"
+	case *ast.MapType:
+		return "map[" + exprString(t.Key) + "]" + exprString(t.Value)
+	}
+	return fmt.Sprintf("%T", e)
+}
+
+// toDBName converts a Go identifier to a column name the way GORM's default
+// naming strategy does, keeping initialisms together:
+//
+//	"AuthorID"  → "author_id"
+//	"AvatarURL" → "avatar_url"
+//	"HTTPCode"  → "http_code"
+func toDBName(s string) string {
+	runes := []rune(s)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) ||
+				(unicode.IsUpper(runes[i-1]) && i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				b.WriteRune('_')
+			}
+			b.WriteRune(unicode.ToLower(r))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// ──────────────────────────────────────────────
+// Column types
+// ──────────────────────────────────────────────
+
+func isIntegerType(goType string) bool {
+	switch goType {
+	case "int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64":
+		return true
+	}
+	return false
+}
+
+// columnType maps a Go type to the column type GORM's driver for dialect
+// would choose. keyed is true for columns that are part of an index or key
+// (MySQL cannot index longtext), autoInc for auto-increment primary keys.
+func columnType(dialect, goType string, size int, keyed, autoInc bool) (string, bool) {
+	switch goType {
+	case "string", "sql.NullString":
+		switch {
+		case dialect == "sqlite":
+			return "text", true
+		case size > 0:
+			return fmt.Sprintf("varchar(%d)", size), true
+		case dialect == "mysql" && keyed:
+			return "varchar(191)", true
+		case dialect == "mysql":
+			return "longtext", true
+		}
+		return "text", true
+
+	case "bool", "sql.NullBool":
+		if dialect == "sqlite" {
+			return "numeric", true
+		}
+		return "boolean", true
+
+	case "int", "int64", "uint", "uint64", "sql.NullInt64":
+		switch dialect {
+		case "sqlite":
+			return "integer", true
+		case "mysql":
+			typ := "bigint"
+			if strings.HasPrefix(goType, "uint") {
+				typ += " unsigned"
+			}
+			if autoInc {
+				typ += " AUTO_INCREMENT"
+			}
+			return typ, true
+		}
+		if autoInc {
+			return "bigserial", true
+		}
+		return "bigint", true
+
+	case "int32", "uint32", "sql.NullInt32":
+		switch dialect {
+		case "sqlite":
+			return "integer", true
+		case "mysql":
+			return "int", true
+		}
+		if autoInc {
+			return "serial", true
+		}
+		return "integer", true
+
+	case "int8", "int16", "uint8", "uint16", "sql.NullInt16":
+		if dialect == "sqlite" {
+			return "integer", true
+		}
+		return "smallint", true
+
+	case "float64", "float32", "sql.NullFloat64":
+		switch dialect {
+		case "sqlite":
+			return "real", true
+		case "mysql":
+			return "double", true
+		}
+		return "decimal", true
+
+	case "time.Time", "gorm.DeletedAt", "sql.NullTime":
+		switch dialect {
+		case "sqlite":
+			return "datetime", true
+		case "mysql":
+			return "datetime(3)", true
+		}
+		return "timestamptz", true
+
+	case "[]byte":
+		switch dialect {
+		case "sqlite":
+			return "blob", true
+		case "mysql":
+			return "longblob", true
+		}
+		return "bytea", true
+
+	case "uuid.UUID":
+		switch dialect {
+		case "sqlite":
+			return "text", true
+		case "mysql":
+			return "char(36)", true
+		}
+		return "uuid", true
+
+	case "datatypes.JSON", "json.RawMessage":
+		if dialect == "postgres" {
+			return "jsonb", true
+		}
+		return "json", true
+	}
+	return "", false
+}
@@ -0,0 +1,338 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ──────────────────────────────────────────────
+// Schema diff
+// ──────────────────────────────────────────────
+
+// schemaChange is one statement of a generated migration, preceded by an
+// Atlas-style comment line.
+type schemaChange struct {
+	Comment     string
+	SQL         string
+	Destructive bool
+}
+
+// diffSchemas returns the statements turning from into to. The order avoids
+// dependency problems: constraints and indexes are dropped first, tables are
+// created and altered next, new indexes and constraints follow, and removed
+// tables are dropped last.
+func diffSchemas(dialect string, from, to *dbSchema) ([]schemaChange, error) {
+	d := sqlDialect(dialect)
+	var dropFKs, dropIdx, create, alter, addIdx, addFKs, dropTables []schemaChange
+
+	for _, t := range to.Tables {
+		old := from.table(t.Name)
+		if old == nil {
+			create = append(create, d.createTable(t))
+			for _, idx := range t.Indexes {
+				addIdx = append(addIdx, d.createIndex(t, idx))
+			}
+			if dialect != "sqlite" {
+				for _, fk := range t.ForeignKeys {
+					addFKs = append(addFKs, d.addForeignKey(t, fk))
+				}
+			}
+			continue
+		}
+
+		if strings.Join(old.PrimaryKey, ",") != strings.Join(t.PrimaryKey, ",") {
+			return nil, fmt.Errorf(
+				"table %q: changing the primary key is not supported offline — generate this migration with Atlas",
+				t.Name,
+			)
+		}
+
+		for _, fk := range old.ForeignKeys {
+			if cur, ok := t.foreignKey(fk.Name); !ok || cur != fk {
+				change, err := d.dropForeignKey(t, fk)
+				if err != nil {
+					return nil, err
+				}
+				dropFKs = append(dropFKs, change)
+			}
+		}
+		for _, idx := range old.Indexes {
+			if cur, ok := t.index(idx.Name); !ok || !sameIndex(cur, idx) {
+				dropIdx = append(dropIdx, d.dropIndex(t, idx))
+			}
+		}
+
+		changes, err := d.alterTable(old, t)
+		if err != nil {
+			return nil, err
+		}
+		alter = append(alter, changes...)
+
+		for _, idx := range t.Indexes {
+			if prev, ok := old.index(idx.Name); !ok || !sameIndex(prev, idx) {
+				addIdx = append(addIdx, d.createIndex(t, idx))
+			}
+		}
+		for _, fk := range t.ForeignKeys {
+			if prev, ok := old.foreignKey(fk.Name); !ok || prev != fk {
+				if dialect == "sqlite" {
+					return nil, fmt.Errorf(
+						"table %q: SQLite cannot add foreign keys to an existing table — generate this migration with Atlas",
+						t.Name,
+					)
+				}
+				addFKs = append(addFKs, d.addForeignKey(t, fk))
+			}
+		}
+	}
+
+	for _, t := range from.Tables {
+		if to.table(t.Name) == nil {
+			// Drop the table's own constraints first so the tables can go in
+			// any order.
+			if dialect != "sqlite" {
+				for _, fk := range t.ForeignKeys {
+					change, err := d.dropForeignKey(t, fk)
+					if err != nil {
+						return nil, err
+					}
+					dropFKs = append(dropFKs, change)
+				}
+			}
+			dropTables = append(dropTables, schemaChange{
+				Comment:     fmt.Sprintf("Drop %q table", t.Name),
+				SQL:         "DROP TABLE " + d.quote(t.Name) + ";",
+				Destructive: true,
+			})
+		}
+	}
+
+	var out []schemaChange
+	for _, group := range [][]schemaChange{dropFKs, dropIdx, create, alter, addIdx, addFKs, dropTables} {
+		out = append(out, group...)
+	}
+	return out, nil
+}
+
+func sameIndex(a, b dbIndex) bool {
+	return a.Unique == b.Unique && strings.Join(a.Columns, ",") == strings.Join(b.Columns, ",")
+}
+
+// renderMigration joins changes into the body of a migration file in the
+// layout Atlas uses: a comment line above every statement.
+func renderMigration(changes []schemaChange) []byte {
+	var sb strings.Builder
+	for _, c := range changes {
+		sb.WriteString("-- " + c.Comment + "\n")
+		sb.WriteString(c.SQL + "\n")
+	}
+	return []byte(sb.String())
+}
+
+// ──────────────────────────────────────────────
+// SQL rendering
+// ──────────────────────────────────────────────
+
+// sqlDialect renders DDL statements for one of the supported dialects.
+type sqlDialect string
+
+func (d sqlDialect) quote(name string) string {
+	if d == "mysql" {
+		return "`" + name + "`"
+	}
+	return `"` + name + `"`
+}
+
+func (d sqlDialect) quoteList(names []string) string {
+	quoted := make([]string, len(names))
+	for i, n := range names {
+		quoted[i] = d.quote(n)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func (d sqlDialect) columnDef(c dbColumn) string {
+	def := d.quote(c.Name) + " " + c.Type
+	if !c.Null {
+		def += " NOT NULL"
+	} else if d != "sqlite" {
+		def += " NULL"
+	}
+	if c.Default != "" {
+		def += " DEFAULT " + d.defaultExpr(c.Default)
+	}
+	return def
+}
+
+// defaultExpr returns a column default as SQL. SQLite only accepts function
+// calls and other expressions as defaults when they are parenthesised.
+func (d sqlDialect) defaultExpr(v string) string {
+	if d == "sqlite" && strings.Contains(v, "(") && !strings.HasPrefix(v, "(") {
+		return "(" + v + ")"
+	}
+	return v
+}
+
+func (d sqlDialect) foreignKeyDef(fk dbForeignKey) string {
+	def := fmt.Sprintf(
+		"CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s (%s)",
+		d.quote(fk.Name), d.quote(fk.Column), d.quote(fk.RefTable), d.quote(fk.RefColumn),
+	)
+	if fk.OnUpdate != "" {
+		def += " ON UPDATE " + fk.OnUpdate
+	}
+	if fk.OnDelete != "" {
+		def += " ON DELETE " + fk.OnDelete
+	}
+	return def
+}
+
+func (d sqlDialect) createTable(t *dbTable) schemaChange {
+	var lines []string
+	for _, c := range t.Columns {
+		lines = append(lines, "  "+d.columnDef(c))
+	}
+	if len(t.PrimaryKey) > 0 {
+		lines = append(lines, "  PRIMARY KEY ("+d.quoteList(t.PrimaryKey)+")")
+	}
+	// SQLite cannot add constraints later, so they are declared inline.
+	if d == "sqlite" {
+		for _, fk := range t.ForeignKeys {
+			lines = append(lines, "  "+d.foreignKeyDef(fk))
+		}
+	}
+	return schemaChange{
+		Comment: fmt.Sprintf("Create %q table", t.Name),
+		SQL:     "CREATE TABLE " + d.quote(t.Name) + " (\n" + strings.Join(lines, ",\n") + "\n);",
+	}
+}
+
+func (d sqlDialect) createIndex(t *dbTable, idx dbIndex) schemaChange {
+	kind := "INDEX"
+	if idx.Unique {
+		kind = "UNIQUE INDEX"
+	}
+	return schemaChange{
+		Comment: fmt.Sprintf("Create index %q to table: %q", idx.Name, t.Name),
+		SQL: fmt.Sprintf(
+			"CREATE %s %s ON %s (%s);",
+			kind, d.quote(idx.Name), d.quote(t.Name), d.quoteList(idx.Columns),
+		),
+	}
+}
+
+func (d sqlDialect) dropIndex(t *dbTable, idx dbIndex) schemaChange {
+	stmt := "DROP INDEX " + d.quote(idx.Name) + ";"
+	if d == "mysql" {
+		stmt = "DROP INDEX " + d.quote(idx.Name) + " ON " + d.quote(t.Name) + ";"
+	}
+	return schemaChange{
+		Comment: fmt.Sprintf("Drop index %q from table: %q", idx.Name, t.Name),
+		SQL:     stmt,
+	}
+}
+
+func (d sqlDialect) addForeignKey(t *dbTable, fk dbForeignKey) schemaChange {
+	return schemaChange{
+		Comment: fmt.Sprintf("Add foreign key %q to table: %q", fk.Name, t.Name),
+		SQL:     "ALTER TABLE " + d.quote(t.Name) + " ADD " + d.foreignKeyDef(fk) + ";",
+	}
+}
+
+func (d sqlDialect) dropForeignKey(t *dbTable, fk dbForeignKey) (schemaChange, error) {
+	var stmt string
+	switch d {
+	case "sqlite":
+		return schemaChange{}, fmt.Errorf(
+			"table %q: SQLite cannot drop foreign keys from an existing table — generate this migration with Atlas",
+			t.Name,
+		)
+	case "mysql":
+		stmt = "ALTER TABLE " + d.quote(t.Name) + " DROP FOREIGN KEY " + d.quote(fk.Name) + ";"
+	default:
+		stmt = "ALTER TABLE " + d.quote(t.Name) + " DROP CONSTRAINT " + d.quote(fk.Name) + ";"
+	}
+	return schemaChange{
+		Comment: fmt.Sprintf("Drop foreign key %q from table: %q", fk.Name, t.Name),
+		SQL:     stmt,
+	}, nil
+}
+
+// alterTable returns the column changes between two versions of a table.
+// PostgreSQL and MySQL get a single ALTER TABLE with one clause per change;
+// SQLite only supports one change per statement and no column modification.
+func (d sqlDialect) alterTable(from, to *dbTable) ([]schemaChange, error) {
+	var clauses []string
+	destructive := false
+
+	for _, c := range to.Columns {
+		prev, ok := from.column(c.Name)
+		if !ok {
+			clauses = append(clauses, "ADD COLUMN "+d.columnDef(c))
+			continue
+		}
+		if prev == c {
+			continue
+		}
+		switch d {
+		case "sqlite":
+			return nil, fmt.Errorf(
+				"table %q: SQLite cannot modify column %q in place — generate this migration with Atlas",
+				to.Name, c.Name,
+			)
+		case "mysql":
+			clauses = append(clauses, "MODIFY COLUMN "+d.columnDef(c))
+		default:
+			col := "ALTER COLUMN " + d.quote(c.Name)
+			if prev.Type != c.Type {
+				clauses = append(clauses, col+" TYPE "+c.Type)
+			}
+			if prev.Null != c.Null {
+				if c.Null {
+					clauses = append(clauses, col+" DROP NOT NULL")
+				} else {
+					clauses = append(clauses, col+" SET NOT NULL")
+				}
+			}
+			if prev.Default != c.Default {
+				if c.Default == "" {
+					clauses = append(clauses, col+" DROP DEFAULT")
+				} else {
+					clauses = append(clauses, col+" SET DEFAULT "+d.defaultExpr(c.Default))
+				}
+			}
+		}
+	}
+
+	for _, c := range from.Columns {
+		if _, ok := to.column(c.Name); !ok {
+			clauses = append(clauses, "DROP COLUMN "+d.quote(c.Name))
+			destructive = true
+		}
+	}
+
+	if len(clauses) == 0 {
+		return nil, nil
+	}
+
+	comment := fmt.Sprintf("Modify %q table", to.Name)
+	prefix := "ALTER TABLE " + d.quote(to.Name) + " "
+
+	if d == "sqlite" {
+		out := make([]schemaChange, len(clauses))
+		for i, clause := range clauses {
+			out[i] = schemaChange{
+				Comment:     comment,
+				SQL:         prefix + clause + ";",
+				Destructive: strings.HasPrefix(clause, "DROP "),
+			}
+		}
+		return out, nil
+	}
+
+	return []schemaChange{{
+		Comment:     comment,
+		SQL:         prefix + strings.Join(clauses, ", ") + ";",
+		Destructive: destructive,
+	}}, nil
+}
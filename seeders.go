@@ -0,0 +1,223 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ──────────────────────────────────────────────
+// Seeders
+// ──────────────────────────────────────────────
+//
+// A Grove project keeps its seeders in internal/database/seeders/: one file
+// per seeder plus seeders.go, whose All slice lists them in the order
+// "grove db:seed" runs them. cmd/seed/main.go is the small program db:seed
+// compiles and runs; all three are created with the first seeder.
+
+// seedersDir is the package holding the project's seeders.
+var seedersDir = filepath.Join("internal", "database", "seeders")
+
+// seedersRegistryPath is the file declaring the All slice.
+var seedersRegistryPath = filepath.Join(seedersDir, "seeders.go")
+
+// seedMainDir is the command db:seed runs with "go run".
+var seedMainDir = filepath.Join("cmd", "seed")
+
+// seederPath returns internal/database/seeders/<snake>_seeder.go.
+func seederPath(name string) string {
+	return filepath.Join(seedersDir, toSnakeCase(name)+"_seeder.go")
+}
+
+// seederModel returns the model a seeder name refers to: "UserSeeder",
+// "users" and "User" all seed User.
+func seederModel(name string) string {
+	name = toPascalCase(name)
+	if trimmed := strings.TrimSuffix(name, "Seeder"); trimmed != "" {
+		name = trimmed
+	}
+	return toPascalCase(toSingular(toSnakeCase(name)))
+}
+
+func scaffoldSeeder(model string) error {
+	content, err := renderSeeder(model)
+	if err != nil {
+		return err
+	}
+
+	return emitFile("Seeder", model+"Seeder", seederPath(model), content)
+}
+
+func renderSeeder(model string) ([]byte, error) {
+	data := struct {
+		Name   string
+		Model  string
+		Module string
+	}{
+		Name:   model,
+		Model:  model,
+		Module: getModuleName(),
+	}
+
+	return renderStub(seederStub, "seeder", data)
+}
+
+// scaffoldSeedMain creates cmd/seed/main.go when the project has none yet.
+func scaffoldSeedMain() error {
+	path := filepath.Join(seedMainDir, "main.go")
+	if fileExists(path) {
+		return nil
+	}
+
+	data := struct {
+		Module string
+	}{
+		Module: getModuleName(),
+	}
+
+	content, err := renderStub(seedMainStub, "seed_main", data)
+	if err != nil {
+		return err
+	}
+
+	return emitFile("Command", "seed", path, content)
+}
+
+// ──────────────────────────────────────────────
+// Registry
+// ──────────────────────────────────────────────
+
+// registerSeeder appends <model>Seeder to the All slice of seeders.go,
+// creating the registry first when needed. A seeder already listed is left
+// alone, so running it twice is a no-op.
+func registerSeeder(model string) error {
+	fn := model + "Seeder"
+	path := seedersRegistryPath
+
+	created := !fileExists(path)
+
+	var src []byte
+	if created {
+		rendered, err := renderStub(seedersStub, "seeders", nil)
+		if err != nil {
+			return err
+		}
+		src = rendered
+	} else {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		src = raw
+	}
+
+	content, added, err := planSeederRegistration(path, src, fn)
+	if err != nil {
+		if created {
+			return err
+		}
+		fmt.Println(warn("Could not register the seeder automatically: " + err.Error()))
+		fmt.Printf(
+			"    %sAdd it to seeders.All by hand:%s\n             %s{Name: %q, Run: %s},%s\n",
+			colorGray, colorReset, colorGray, fn, fn, colorReset,
+		)
+		return nil
+	}
+
+	switch {
+	case created:
+		if dryRun {
+			return previewFile("Registry", "seeders", path, content, "create")
+		}
+		if err := writeFile(path, content); err != nil {
+			return err
+		}
+		printCreated("Registry", "seeders", path)
+	case !added:
+		if dryRun {
+			printPlanned("Registry", fn, path, "skip")
+			return nil
+		}
+		printSkipped("Registry", fn, path)
+	default:
+		if dryRun {
+			return previewFile("Registry", fn, path, content, "update")
+		}
+		if err := writeFile(path, content); err != nil {
+			return err
+		}
+		printUpdated("Registry", fn, path)
+	}
+	return nil
+}
+
+// planSeederRegistration returns src with an entry for fn appended to the
+// All composite literal. added is false when fn is already referenced in it.
+func planSeederRegistration(path string, src []byte, fn string) ([]byte, bool, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, src, parser.ParseComments)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	lit := seedersList(file)
+	if lit == nil {
+		return nil, false, fmt.Errorf("no \"var All = []Seeder{…}\" found in %s", path)
+	}
+
+	listed := false
+	ast.Inspect(lit, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok && id.Name == fn {
+			listed = true
+		}
+		return !listed
+	})
+	if listed {
+		return src, false, nil
+	}
+
+	entry := fmt.Sprintf("\t{Name: %q, Run: %s},\n", fn, fn)
+
+	// Insert on its own line before the closing brace; a literal closed on
+	// the same line ("[]Seeder{}") is opened up first.
+	rbrace := fset.Position(lit.Rbrace).Offset
+	start := lineStart(src, rbrace)
+	edit := textEdit{Offset: start, Text: entry}
+	if strings.TrimSpace(string(src[start:rbrace])) != "" {
+		edit = textEdit{Offset: rbrace, Text: "\n" + entry}
+	}
+
+	formatted, err := format.Source(applyTextEdits(src, []textEdit{edit}))
+	if err != nil {
+		return nil, false, fmt.Errorf("edited %s does not parse: %w", path, err)
+	}
+	return formatted, true, nil
+}
+
+// seedersList returns the composite literal assigned to the package-level
+// All variable.
+func seedersList(file *ast.File) *ast.CompositeLit {
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.VAR {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			vs := spec.(*ast.ValueSpec)
+			for i, name := range vs.Names {
+				if name.Name != "All" || i >= len(vs.Values) {
+					continue
+				}
+				if lit, ok := vs.Values[i].(*ast.CompositeLit); ok {
+					return lit
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -3,6 +3,8 @@ package main
 import (
 	_ "embed"
 	"fmt"
+	"os"
+	"path/filepath"
 )
 
 // ──────────────────────────────────────────────
@@ -24,6 +26,68 @@ var middlewareStub string
 //go:embed stubs/test_spec.stub
 var testSpecStub string
 
+//go:embed stubs/test_model.stub
+var testModelStub string
+
+//go:embed stubs/factory.stub
+var factoryStub string
+
+//go:embed stubs/seeder.stub
+var seederStub string
+
+//go:embed stubs/seeders.stub
+var seedersStub string
+
+//go:embed stubs/seed_main.stub
+var seedMainStub string
+
+//go:embed stubs/version.stub
+var versionStub string
+
+// embeddedStubs maps each stub name (as passed to renderStub) to its embedded
+// default. The order of stubNames is the order stubs:publish lists them in.
+var embeddedStubs = map[string]string{
+	"model":      modelStub,
+	"controller": controllerStub,
+	"request":    requestStub,
+	"middleware": middlewareStub,
+	"test_spec":  testSpecStub,
+	"test_model": testModelStub,
+	"factory":    factoryStub,
+	"seeder":     seederStub,
+	"seeders":    seedersStub,
+	"seed_main":  seedMainStub,
+	"version":    versionStub,
+}
+
+var stubNames = []string{
+	"model", "controller", "request", "middleware", "test_spec",
+	"test_model", "factory", "seeder", "seeders", "seed_main", "version",
+}
+
+// ──────────────────────────────────────────────
+// Project-local overrides
+// ──────────────────────────────────────────────
+
+// projectStubsDir is where a project keeps its own copies of the stubs.
+// Files are looked up by name, e.g. .grove/stubs/controller.stub.
+var projectStubsDir = filepath.Join(".grove", "stubs")
+
+// resolveStub returns the project-local override for the named stub when
+// .grove/stubs/<name>.stub exists, and fallback (the embedded default)
+// otherwise.
+func resolveStub(name, fallback string) (string, error) {
+	path := filepath.Join(projectStubsDir, name+".stub")
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fallback, nil
+		}
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return string(raw), nil
+}
+
 // ──────────────────────────────────────────────
 // Scaffold output helpers
 // ──────────────────────────────────────────────
@@ -51,3 +115,86 @@ func printSkipped(kind, name, path string) {
 		gray("→ "+path+" (already exists)"),
 	)
 }
+
+// printUpdated prints a blue "UPDATED" badge line when an existing file was
+// edited in place (e.g. routes registered in internal/routes/).
+func printUpdated(kind, name, path string) {
+	fmt.Printf("  %s UPDATED %s  %s %s %s\n",
+		colorBgBlue,
+		colorReset,
+		colorGray+kind+colorReset,
+		bold(name),
+		gray("→ "+path),
+	)
+}
+
+// printPlanned prints a dry-run badge line describing what would happen to
+// path: "create", "skip", "update" or "remove".
+func printPlanned(kind, name, path, action string) {
+	bg, label, note := colorBgGreen, "WOULD CREATE", ""
+	switch action {
+	case "skip":
+		bg, label, note = colorBgYellow, "WOULD SKIP", " (already exists)"
+	case "update":
+		bg, label = colorBgBlue, "WOULD UPDATE"
+	case "remove":
+		bg, label = colorBgRed, "WOULD REMOVE"
+	}
+	fmt.Printf("  %s %s %s  %s %s %s\n",
+		bg,
+		label,
+		colorReset,
+		colorGray+kind+colorReset,
+		bold(name),
+		gray("→ "+path+note),
+	)
+	fmt.Println()
+}
+
+// printMerged prints a green "MERGED" badge line when regenerated content was
+// merged cleanly with the user's edits (--merge).
+func printMerged(kind, name, path string) {
+	fmt.Printf("  %s MERGED %s  %s %s %s\n",
+		colorBgGreen,
+		colorReset,
+		colorGray+kind+colorReset,
+		bold(name),
+		gray("→ "+path),
+	)
+}
+
+// printConflict prints a red "CONFLICT" badge line when a --merge left
+// conflict markers in path.
+func printConflict(kind, name, path string, conflicts int) {
+	fmt.Printf("  %s CONFLICT %s  %s %s %s\n",
+		colorBgRed,
+		colorReset,
+		colorGray+kind+colorReset,
+		bold(name),
+		gray(fmt.Sprintf("→ %s (%d conflict(s) — resolve the <<<<<<< markers)", path, conflicts)),
+	)
+}
+
+// printRemoved prints a red "REMOVED" badge line when a destroy:* command
+// deleted a generated file.
+func printRemoved(kind, name, path string) {
+	fmt.Printf("  %s REMOVED %s  %s %s %s\n",
+		colorBgRed,
+		colorReset,
+		colorGray+kind+colorReset,
+		bold(name),
+		gray("→ "+path),
+	)
+}
+
+// printKept prints a yellow "KEPT" badge line when a destroy:* command left a
+// file in place, with the reason in parentheses.
+func printKept(kind, name, path, reason string) {
+	fmt.Printf("  %s KEPT %s  %s %s %s\n",
+		colorBgYellow,
+		colorReset,
+		colorGray+kind+colorReset,
+		bold(name),
+		gray("→ "+path+" ("+reason+")"),
+	)
+}